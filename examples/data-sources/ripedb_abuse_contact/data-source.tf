@@ -0,0 +1,7 @@
+data "ripedb_abuse_contact" "example" {
+  resource = "193.0.0.1"
+}
+
+output "abuse_mailbox" {
+  value = data.ripedb_abuse_contact.example.email
+}
@@ -0,0 +1,12 @@
+data "ripedb_class_template" "person" {
+  class = "person"
+}
+
+variable "attributes" {
+  type = list(object({ name = string, value = string }))
+
+  validation {
+    condition     = alltrue([for name in data.ripedb_class_template.person.mandatory_attributes : contains(var.attributes[*].name, name) if !contains(["person", "source"], name)])
+    error_message = "The mandatory attributes of a person must be set."
+  }
+}
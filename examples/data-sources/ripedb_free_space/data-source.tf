@@ -0,0 +1,9 @@
+data "ripedb_free_space" "allocation" {
+  parent        = "192.0.2.0/24"
+  prefix_length = 29
+}
+
+# The next free /29 of the allocation, e.g. for a new assignment
+output "next_assignment" {
+  value = data.ripedb_free_space.allocation.first_free
+}
@@ -0,0 +1,12 @@
+data "ripedb_inverse_lookup" "john" {
+  handle             = "JS1-TEST"
+  inverse_attributes = ["admin-c", "tech-c", "zone-c"]
+}
+
+# Fail the plan if the person is still referenced before removing it
+check "john_unreferenced" {
+  assert {
+    condition     = length(data.ripedb_inverse_lookup.john.ids) == 0
+    error_message = "JS1-TEST is still referenced by ${join(", ", data.ripedb_inverse_lookup.john.ids)}"
+  }
+}
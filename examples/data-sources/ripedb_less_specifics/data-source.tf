@@ -0,0 +1,9 @@
+# The parent chain of an assignment, from the allocation down
+data "ripedb_less_specifics" "example" {
+  class = "inetnum"
+  value = "193.0.0.0 - 193.0.7.255"
+}
+
+output "allocation" {
+  value = [for o in data.ripedb_less_specifics.example.objects : o.value if o.status == "ALLOCATED PA"]
+}
@@ -0,0 +1,11 @@
+data "ripedb_maintained_objects" "example" {
+  mntner                = "EXAMPLE-MNT"
+  maintainer_attributes = ["mnt-by", "mnt-lower", "mnt-routes"]
+}
+
+# Bring the routes of the maintainer under management
+import {
+  for_each = toset(lookup(data.ripedb_maintained_objects.example.ids_by_class, "route", []))
+  to       = ripedb_object.route[each.value]
+  id       = each.value
+}
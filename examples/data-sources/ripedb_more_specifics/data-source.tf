@@ -0,0 +1,13 @@
+# The assignments made within an allocation
+data "ripedb_more_specifics" "example" {
+  class      = "inetnum"
+  value      = "193.0.0.0/16"
+  all_levels = true
+}
+
+output "assignments" {
+  value = {
+    for o in data.ripedb_more_specifics.example.objects : o.value => o.mnt_by
+    if o.status == "ASSIGNED PA"
+  }
+}
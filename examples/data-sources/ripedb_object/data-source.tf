@@ -1,4 +1,27 @@
+# The contact attributes, e.g. e-mail, are only returned unfiltered, and
+# masked unless mask_sensitive is false
 data "ripedb_object" "test" {
-  class = "organisation"
-  value = "ORG-TT1-TEST"
+  class          = "organisation"
+  value          = "ORG-TT1-TEST"
+  unfiltered     = true
+  mask_sensitive = false
+}
+
+output "organisation_rpsl" {
+  value = data.ripedb_object.test.rpsl
+}
+
+output "organisation_emails" {
+  value = lookup(data.ripedb_object.test.attributes_map, "e-mail", [])
+}
+
+# Fall back to the team role when the person does not exist
+data "ripedb_object" "john" {
+  class         = "person"
+  value         = "JS1-TEST"
+  allow_missing = true
+}
+
+locals {
+  admin_c = data.ripedb_object.john.found ? data.ripedb_object.john.value : "TEAM1-TEST"
 }
@@ -0,0 +1,11 @@
+data "ripedb_object_versions" "example" {
+  class = "mntner"
+  value = "EXAMPLE-MNT"
+}
+
+# The object as it was before its last change
+data "ripedb_object_version" "previous" {
+  class    = "mntner"
+  value    = "EXAMPLE-MNT"
+  revision = data.ripedb_object_versions.example.versions[length(data.ripedb_object_versions.example.versions) - 2].revision
+}
@@ -0,0 +1,8 @@
+data "ripedb_object_versions" "example" {
+  class = "mntner"
+  value = "EXAMPLE-MNT"
+}
+
+output "last_change" {
+  value = data.ripedb_object_versions.example.versions[length(data.ripedb_object_versions.example.versions) - 1].date
+}
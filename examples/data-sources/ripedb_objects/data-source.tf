@@ -0,0 +1,12 @@
+locals {
+  team = ["JS1-TEST", "JD1-TEST", "AB1-TEST"]
+}
+
+data "ripedb_objects" "team" {
+  ids           = [for handle in local.team : "person:${handle}"]
+  allow_missing = true
+}
+
+output "team_names" {
+  value = { for id, person in data.ripedb_objects.team.objects : id => provider::ripedb::get_first(person.attributes, "person") }
+}
@@ -0,0 +1,7 @@
+# All the route objects originated by AS3333
+data "ripedb_search" "routes" {
+  query_string      = "AS3333"
+  type_filter       = ["route", "route6"]
+  inverse_attribute = ["origin"]
+  flags             = ["r"]
+}
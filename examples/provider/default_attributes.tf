@@ -0,0 +1,9 @@
+provider "ripe" {
+  api_key = var.api_key
+
+  default_attributes = [
+    { name = "mnt-by", value = "EXAMPLE-MNT" },
+    { name = "notify", value = "noc@example.com" },
+    { name = "org", value = "ORG-EXA1-RIPE", classes = ["aut-num", "inetnum", "inet6num"] },
+  ]
+}
@@ -0,0 +1,15 @@
+# Serve the lookups from the split dumps downloaded from
+# https://ftp.ripe.net/ripe/dbase/split/
+provider "ripedb" {
+  backend   = "dump"
+  dump_path = "${path.module}/dumps"
+}
+
+data "ripedb_object" "as3333" {
+  class = "aut-num"
+  value = "AS3333"
+}
+
+output "as_name" {
+  value = provider::ripedb::get_first(data.ripedb_object.as3333.attributes, "as-name")
+}
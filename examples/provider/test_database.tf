@@ -0,0 +1,5 @@
+provider "ripe" {
+  environment     = "test"
+  rewrite_handles = true
+  api_key         = var.test_api_key
+}
@@ -0,0 +1,6 @@
+# Look up the objects over the whois protocol, e.g. where only whois egress
+# is allowed.
+provider "ripedb" {
+  backend       = "whois"
+  whois_address = "whois.ripe.net:43"
+}
@@ -0,0 +1,184 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package iprange parses the address ranges of the inetnum, inet6num, route
+// and route6 objects and resolves their hierarchy, for the provider and its
+// test server alike.
+package iprange
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/frederic-arr/rpsl-go"
+)
+
+// Classes are the classes of the objects describing address space.
+var Classes = []string{"inetnum", "inet6num", "route", "route6"}
+
+// HierarchyFlags are the query flags for the less specific (`L`, `l`) and
+// more specific (`M`, `m`) objects.
+var HierarchyFlags = []string{"L", "l", "M", "m"}
+
+// Range is an inclusive range of IP addresses of the same family.
+type Range struct {
+	First netip.Addr
+	Last  netip.Addr
+}
+
+// Parse parses an IP address, a prefix or a range written `first - last`, as
+// found in the keys of the inetnum, inet6num, route and route6 objects.
+func Parse(value string) (Range, error) {
+	if start, end, ok := strings.Cut(value, "-"); ok {
+		first, err := netip.ParseAddr(strings.TrimSpace(start))
+		if err != nil {
+			return Range{}, err
+		}
+
+		last, err := netip.ParseAddr(strings.TrimSpace(end))
+		if err != nil {
+			return Range{}, err
+		}
+
+		if first.Is4() != last.Is4() || last.Less(first) {
+			return Range{}, fmt.Errorf("invalid address range %q", value)
+		}
+
+		return Range{First: first, Last: last}, nil
+	}
+
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return Range{First: addr, Last: addr}, nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return Range{}, err
+	}
+
+	return PrefixRange(prefix), nil
+}
+
+// PrefixRange returns the range of addresses of a prefix.
+func PrefixRange(prefix netip.Prefix) Range {
+	prefix = prefix.Masked()
+	last := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(last)*8; i++ {
+		last[i/8] |= 1 << (7 - i%8)
+	}
+
+	end, _ := netip.AddrFromSlice(last)
+	return Range{First: prefix.Addr(), Last: end}
+}
+
+// ObjectRange returns the range of addresses of an inetnum, inet6num, route
+// or route6 object.
+func ObjectRange(obj *rpsl.Object) (Range, error) {
+	if len(obj.Attributes) == 0 || !slices.Contains(Classes, obj.Attributes[0].Name) {
+		return Range{}, fmt.Errorf("not an address space object")
+	}
+
+	return Parse(obj.Attributes[0].Value)
+}
+
+// Contains reports whether the range contains the other one.
+func (r Range) Contains(other Range) bool {
+	return r.First.Is4() == other.First.Is4() && r.First.Compare(other.First) <= 0 && r.Last.Compare(other.Last) >= 0
+}
+
+// Compare orders the ranges by first address, then the larger range first,
+// so that a range comes before the ranges it contains.
+func (r Range) Compare(other Range) int {
+	if c := r.First.Compare(other.First); c != 0 {
+		return c
+	}
+
+	return other.Last.Compare(r.Last)
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%s - %s", r.First, r.Last)
+}
+
+// Hierarchy returns the objects less or more specific than the query range
+// for one of the HierarchyFlags, like the RIPE database does: `L` returns all
+// the less specific objects and the exact match, `l` the first level of less
+// specific objects, `M` all the more specific objects and `m` their first
+// level. The objects are sorted by range, less specific first.
+func Hierarchy(query Range, objects []*rpsl.Object, flag string) []*rpsl.Object {
+	type ranged struct {
+		obj *rpsl.Object
+		r   Range
+	}
+
+	matches := []ranged{}
+	for _, obj := range objects {
+		r, err := ObjectRange(obj)
+		if err != nil {
+			continue
+		}
+
+		switch flag {
+		case "L":
+			if r.Contains(query) {
+				matches = append(matches, ranged{obj, r})
+			}
+		case "l":
+			if r.Contains(query) && r != query {
+				matches = append(matches, ranged{obj, r})
+			}
+		case "M", "m":
+			if query.Contains(r) && r != query {
+				matches = append(matches, ranged{obj, r})
+			}
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b ranged) int { return a.r.Compare(b.r) })
+
+	result := []*rpsl.Object{}
+	for _, m := range matches {
+		switch flag {
+		case "l":
+			// The first level is the most specific of the less specific
+			// ranges, which all contain each other.
+			if m.r != matches[len(matches)-1].r {
+				continue
+			}
+		case "m":
+			// A first level range is not contained in another match.
+			if slices.ContainsFunc(matches, func(o ranged) bool { return o.r != m.r && o.r.Contains(m.r) }) {
+				continue
+			}
+		}
+
+		result = append(result, m.obj)
+	}
+
+	return result
+}
+
+// Prefixes returns the smallest list of prefixes covering exactly the range.
+func (r Range) Prefixes() []netip.Prefix {
+	prefixes := []netip.Prefix{}
+	start := r.First
+	for {
+		var p netip.Prefix
+		for bits := 0; bits <= start.BitLen(); bits++ {
+			p = netip.PrefixFrom(start, bits)
+			if p.Masked().Addr() == start && PrefixRange(p).Last.Compare(r.Last) <= 0 {
+				break
+			}
+		}
+
+		prefixes = append(prefixes, p)
+		last := PrefixRange(p).Last
+		if last == r.Last {
+			return prefixes
+		}
+
+		start = last.Next()
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package iprange
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/frederic-arr/rpsl-go"
+)
+
+func TestParse(t *testing.T) {
+	for value, expected := range map[string]string{
+		"192.0.2.1":                  "192.0.2.1 - 192.0.2.1",
+		"192.0.2.0/24":               "192.0.2.0 - 192.0.2.255",
+		"192.0.2.77/26":              "192.0.2.64 - 192.0.2.127",
+		"192.0.2.0 - 192.0.2.127":    "192.0.2.0 - 192.0.2.127",
+		"2001:db8::/32":              "2001:db8:: - 2001:db8:ffff:ffff:ffff:ffff:ffff:ffff",
+		"2001:db8::1 - 2001:db8::ff": "2001:db8::1 - 2001:db8::ff",
+	} {
+		r, err := Parse(value)
+		if err != nil {
+			t.Errorf("%s: %v", value, err)
+			continue
+		}
+
+		if r.String() != expected {
+			t.Errorf("%s: expected %s, got %s", value, expected, r)
+		}
+	}
+
+	for _, value := range []string{"", "192.0.2.0/33", "192.0.2.255 - 192.0.2.0", "192.0.2.0 - 2001:db8::"} {
+		if _, err := Parse(value); err == nil {
+			t.Errorf("%s: expected an error", value)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ranges := []Range{}
+	for _, value := range []string{"192.0.2.128/25", "2001:db8::/32", "192.0.2.0/25", "192.0.2.0/24", "192.0.0.0/16"} {
+		r, err := Parse(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	slices.SortFunc(ranges, Range.Compare)
+
+	keys := []string{}
+	for _, r := range ranges {
+		keys = append(keys, r.String())
+	}
+
+	expected := []string{
+		"192.0.0.0 - 192.0.255.255",
+		"192.0.2.0 - 192.0.2.255",
+		"192.0.2.0 - 192.0.2.127",
+		"192.0.2.128 - 192.0.2.255",
+		"2001:db8:: - 2001:db8:ffff:ffff:ffff:ffff:ffff:ffff",
+	}
+
+	if !slices.Equal(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
+
+func TestHierarchy(t *testing.T) {
+	objects := []*rpsl.Object{}
+	for _, text := range []string{
+		"inetnum: 192.0.2.64 - 192.0.2.127\n",
+		"inetnum: 192.0.0.0 - 192.0.255.255\n",
+		"inetnum: 192.0.2.0 - 192.0.2.255\n",
+		"inetnum: 192.0.2.96 - 192.0.2.97\n",
+		"inetnum: 198.51.100.0 - 198.51.100.255\n",
+		"inet6num: 2001:db8::/32\n",
+	} {
+		obj, err := rpsl.Parse(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	query, _ := Parse("192.0.2.0/24")
+	for flag, expected := range map[string][]string{
+		"L": {"192.0.0.0 - 192.0.255.255", "192.0.2.0 - 192.0.2.255"},
+		"l": {"192.0.0.0 - 192.0.255.255"},
+		"M": {"192.0.2.64 - 192.0.2.127", "192.0.2.96 - 192.0.2.97"},
+		"m": {"192.0.2.64 - 192.0.2.127"},
+	} {
+		keys := []string{}
+		for _, obj := range Hierarchy(query, objects, flag) {
+			keys = append(keys, obj.Attributes[0].Value)
+		}
+
+		if !slices.Equal(keys, expected) {
+			t.Errorf("-%s: expected %v, got %v", flag, expected, keys)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AbuseContactDataSource{}
+
+func NewAbuseContactDataSource() datasource.DataSource {
+	return &AbuseContactDataSource{}
+}
+
+type AbuseContactDataSource struct {
+	client RipeDbClient
+}
+
+type AbuseContactDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Resource     types.String `tfsdk:"resource"`
+	Email        types.String `tfsdk:"email"`
+	Role         types.String `tfsdk:"role"`
+	Organisation types.String `tfsdk:"organisation"`
+	Suspect      types.Bool   `tfsdk:"suspect"`
+}
+
+func (d *AbuseContactDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_abuse_contact"
+}
+
+func (d *AbuseContactDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source provides the abuse contact responsible for an IP address, prefix or AS number, as resolved by the RIPE Database from the `abuse-c` of the resource or of its organisation.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the ID of the data source, equal to `resource`",
+				Computed:            true,
+			},
+			"resource": schema.StringAttribute{
+				MarkdownDescription: "the IP address, prefix, range or AS number, e.g. `193.0.0.1`, `2001:db8::/32` or `AS3333`",
+				Required:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "the `abuse-mailbox` of the abuse contact",
+				Computed:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "the nic-hdl of the role object holding the abuse mailbox",
+				Computed:            true,
+			},
+			"organisation": schema.StringAttribute{
+				MarkdownDescription: "the organisation of the resource, if any",
+				Computed:            true,
+			},
+			"suspect": schema.BoolAttribute{
+				MarkdownDescription: "whether the abuse mailbox failed its validation by the RIPE NCC",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AbuseContactDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *AbuseContactDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	client, ok := clientCapability[AbuseContactClient](d.client, "abuse contacts", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var data AbuseContactDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resource := data.Resource.ValueString()
+	contact, err := client.GetAbuseContact(ctx, resource)
+	if isNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resource"),
+			"No abuse contact",
+			fmt.Sprintf("No abuse contact is set for %s. The `abuse-c` attribute must be set on the resource, on one of the resources covering it, or on its organisation.", resource),
+		)
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to query RIPE database", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(resource)
+	data.Email = types.StringValue(contact.Email)
+	data.Role = types.StringValue(contact.Key)
+	data.Organisation = types.StringNull()
+	if contact.Organisation != "" {
+		data.Organisation = types.StringValue(contact.Organisation)
+	}
+
+	data.Suspect = types.BoolValue(contact.Suspect)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
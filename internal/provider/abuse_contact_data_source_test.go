@@ -0,0 +1,52 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAbuseContactDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	for _, obj := range []string{
+		"role: Abuse\nnic-hdl: AB1-TEST\nabuse-mailbox: abuse@example.net\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"organisation: ORG-EX1-TEST\nabuse-c: AB1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.255\norg: ORG-EX1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"aut-num: AS64496\nmnt-by: TEST-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_abuse_contact" "test" {
+					resource = "192.0.2.0/25"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_abuse_contact.test", "email", "abuse@example.net"),
+					resource.TestCheckResourceAttr("data.ripedb_abuse_contact.test", "role", "AB1-TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_abuse_contact.test", "organisation", "ORG-EX1-TEST"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_abuse_contact" "test" {
+					resource = "AS64496"
+				}
+				`,
+				ExpectError: regexp.MustCompile("No abuse contact is set for AS64496"),
+			},
+		},
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/netip"
+	"slices"
+
+	"terraform-provider-ripedb/internal/iprange"
+)
+
+// freeRanges returns the ranges of the parent not covered by any of the used
+// ranges.
+func freeRanges(parent iprange.Range, used []iprange.Range) []iprange.Range {
+	used = slices.Clone(used)
+	slices.SortFunc(used, func(a, b iprange.Range) int { return a.First.Compare(b.First) })
+
+	free := []iprange.Range{}
+	next := parent.First
+	for _, r := range used {
+		if !parent.Contains(r) || r.Last.Less(next) {
+			continue
+		}
+
+		if next.Less(r.First) {
+			free = append(free, iprange.Range{First: next, Last: r.First.Prev()})
+		}
+
+		if r.Last == parent.Last {
+			return free
+		}
+
+		next = r.Last.Next()
+	}
+
+	return append(free, iprange.Range{First: next, Last: parent.Last})
+}
+
+// splitPrefix returns the prefixes of the given length within the prefix, or
+// nothing when the prefix is smaller. A positive limit caps the number of
+// prefixes returned.
+func splitPrefix(prefix netip.Prefix, bits int, limit int) []netip.Prefix {
+	prefixes := []netip.Prefix{}
+	if prefix.Bits() > bits || bits > prefix.Addr().BitLen() {
+		return prefixes
+	}
+
+	for start := prefix.Addr(); start.IsValid() && prefix.Contains(start) && (limit <= 0 || len(prefixes) < limit); {
+		p := netip.PrefixFrom(start, bits)
+		prefixes = append(prefixes, p)
+		start = iprange.PrefixRange(p).Last.Next()
+	}
+
+	return prefixes
+}
+
+// freeBlocks returns the prefixes of the parent not covered by the used
+// ranges, in ascending order. When bits is not negative, the free prefixes are
+// split in prefixes of that length and the smaller ones are left out. A
+// positive limit caps the number of prefixes returned.
+func freeBlocks(parent iprange.Range, used []iprange.Range, bits int, limit int) []netip.Prefix {
+	blocks := []netip.Prefix{}
+	for _, r := range freeRanges(parent, used) {
+		for _, p := range r.Prefixes() {
+			if limit > 0 && len(blocks) >= limit {
+				return blocks
+			}
+
+			if bits < 0 {
+				blocks = append(blocks, p)
+				continue
+			}
+
+			remaining := 0
+			if limit > 0 {
+				remaining = limit - len(blocks)
+			}
+
+			blocks = append(blocks, splitPrefix(p, bits, remaining)...)
+		}
+	}
+
+	return blocks
+}
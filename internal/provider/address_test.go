@@ -0,0 +1,50 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"terraform-provider-ripedb/internal/iprange"
+)
+
+func TestFreeBlocks(t *testing.T) {
+	parent, _ := iprange.Parse("192.0.2.0/24")
+	used := []iprange.Range{}
+	for _, value := range []string{"192.0.2.64/26", "192.0.2.0 - 192.0.2.9", "198.51.100.0/24"} {
+		r, _ := iprange.Parse(value)
+		used = append(used, r)
+	}
+
+	for _, tc := range []struct {
+		bits     int
+		limit    int
+		expected []string
+	}{
+		{-1, 0, []string{"192.0.2.10/31", "192.0.2.12/30", "192.0.2.16/28", "192.0.2.32/27", "192.0.2.128/25"}},
+		{-1, 2, []string{"192.0.2.10/31", "192.0.2.12/30"}},
+		{27, 0, []string{"192.0.2.32/27", "192.0.2.128/27", "192.0.2.160/27", "192.0.2.192/27", "192.0.2.224/27"}},
+		{26, 1, []string{"192.0.2.128/26"}},
+	} {
+		blocks := []string{}
+		for _, p := range freeBlocks(parent, used, tc.bits, tc.limit) {
+			blocks = append(blocks, p.String())
+		}
+
+		if !slices.Equal(blocks, tc.expected) {
+			t.Errorf("/%d (limit %d): expected %v, got %v", tc.bits, tc.limit, tc.expected, blocks)
+		}
+	}
+
+	full, _ := iprange.Parse("2001:db8::/32")
+	if blocks := freeBlocks(full, []iprange.Range{full}, -1, 0); len(blocks) != 0 {
+		t.Errorf("expected no free blocks in a full range, got %v", blocks)
+	}
+
+	all, _ := iprange.Parse("::/0")
+	if blocks := freeBlocks(all, nil, 64, 3); len(blocks) != 3 || blocks[2].String() != "0:0:0:2::/64" {
+		t.Errorf("expected the first 3 /64, got %v", blocks)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ClassTemplateDataSource{}
+
+func NewClassTemplateDataSource() datasource.DataSource {
+	return &ClassTemplateDataSource{}
+}
+
+type ClassTemplateDataSource struct {
+	client RipeDbClient
+}
+
+type ClassTemplateDataSourceModel struct {
+	Id                  types.String                  `tfsdk:"id"`
+	Class               types.String                  `tfsdk:"class"`
+	Attributes          []ClassTemplateAttributeModel `tfsdk:"attributes"`
+	PrimaryKey          types.List                    `tfsdk:"primary_key"`
+	MandatoryAttributes types.List                    `tfsdk:"mandatory_attributes"`
+	SingleAttributes    types.List                    `tfsdk:"single_attributes"`
+}
+
+type ClassTemplateAttributeModel struct {
+	Name        types.String `tfsdk:"name"`
+	Requirement types.String `tfsdk:"requirement"`
+	Cardinality types.String `tfsdk:"cardinality"`
+	PrimaryKey  types.Bool   `tfsdk:"primary_key"`
+	LookupKey   types.Bool   `tfsdk:"lookup_key"`
+	InverseKey  types.Bool   `tfsdk:"inverse_key"`
+}
+
+func (d *ClassTemplateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_class_template"
+}
+
+func (d *ClassTemplateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source provides the template of a class of the RIPE Database: its attributes, whether they are mandatory and repeatable, and which are keys. It can back the validations of the modules creating objects.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the class",
+				Computed:            true,
+			},
+			"class": schema.StringAttribute{
+				MarkdownDescription: "the class, e.g. `inetnum` or `mntner`",
+				Required:            true,
+			},
+			"attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "the attributes of the class, in the order of the template",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "the name of the attribute",
+							Computed:            true,
+						},
+						"requirement": schema.StringAttribute{
+							MarkdownDescription: "whether the attribute is `mandatory`, `optional`, `conditional` or `generated` by the database",
+							Computed:            true,
+						},
+						"cardinality": schema.StringAttribute{
+							MarkdownDescription: "whether the attribute can appear once (`single`) or several times (`multiple`)",
+							Computed:            true,
+						},
+						"primary_key": schema.BoolAttribute{
+							MarkdownDescription: "whether the attribute is part of the primary key",
+							Computed:            true,
+						},
+						"lookup_key": schema.BoolAttribute{
+							MarkdownDescription: "whether the objects can be looked up by the attribute",
+							Computed:            true,
+						},
+						"inverse_key": schema.BoolAttribute{
+							MarkdownDescription: "whether the attribute can be used for inverse lookups",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"primary_key": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the names of the attributes making up the primary key, e.g. `route` and `origin` for the `route` class",
+				Computed:            true,
+			},
+			"mandatory_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the names of the mandatory attributes",
+				Computed:            true,
+			},
+			"single_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the names of the attributes which can appear only once",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ClassTemplateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *ClassTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	client, ok := clientCapability[TemplateClient](d.client, "class templates", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var data ClassTemplateDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := client.GetTemplate(ctx, data.Class.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("failed to query RIPE database", err.Error())
+		return
+	}
+
+	primaryKey := []string{}
+	mandatory := []string{}
+	single := []string{}
+	data.Attributes = []ClassTemplateAttributeModel{}
+	for _, a := range template.Attributes {
+		data.Attributes = append(data.Attributes, ClassTemplateAttributeModel{
+			Name:        types.StringValue(a.Name),
+			Requirement: types.StringValue(a.Requirement),
+			Cardinality: types.StringValue(a.Cardinality),
+			PrimaryKey:  types.BoolValue(a.PrimaryKey),
+			LookupKey:   types.BoolValue(a.LookupKey),
+			InverseKey:  types.BoolValue(a.InverseKey),
+		})
+
+		if a.PrimaryKey {
+			primaryKey = append(primaryKey, a.Name)
+		}
+
+		if a.Requirement == "mandatory" {
+			mandatory = append(mandatory, a.Name)
+		}
+
+		if a.Cardinality == "single" {
+			single = append(single, a.Name)
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.PrimaryKey, diags = types.ListValueFrom(ctx, types.StringType, primaryKey)
+	resp.Diagnostics.Append(diags...)
+	data.MandatoryAttributes, diags = types.ListValueFrom(ctx, types.StringType, mandatory)
+	resp.Diagnostics.Append(diags...)
+	data.SingleAttributes, diags = types.ListValueFrom(ctx, types.StringType, single)
+	resp.Diagnostics.Append(diags...)
+
+	data.Id = data.Class
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccClassTemplateDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	if err := srv.AddTemplate("mntner", `
+		mntner:         mandatory   single     primary/lookup key
+		descr:          optional    multiple
+		admin-c:        mandatory   multiple   inverse key
+		upd-to:         mandatory   multiple   inverse key
+		auth:           mandatory   multiple   inverse key
+		mnt-by:         mandatory   multiple   inverse key
+		created:        generated   single
+		last-modified:  generated   single
+		source:         mandatory   single
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_class_template" "test" {
+					class = "mntner"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_class_template.test", "attributes.#", "9"),
+					resource.TestCheckResourceAttr("data.ripedb_class_template.test", "attributes.2.name", "admin-c"),
+					resource.TestCheckResourceAttr("data.ripedb_class_template.test", "attributes.2.inverse_key", "true"),
+					resource.TestCheckResourceAttr("data.ripedb_class_template.test", "primary_key.#", "1"),
+					resource.TestCheckResourceAttr("data.ripedb_class_template.test", "primary_key.0", "mntner"),
+					resource.TestCheckResourceAttr("data.ripedb_class_template.test", "mandatory_attributes.#", "6"),
+					resource.TestCheckResourceAttr("data.ripedb_class_template.test", "single_attributes.#", "4"),
+				),
+			},
+		},
+	})
+}
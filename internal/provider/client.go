@@ -0,0 +1,158 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Ensure RestClient satisfies the RipeDbClient interface.
+var _ RipeDbClient = &RestClient{}
+
+// RipeDbClient is the RIPE database backend used by the resources and data
+// sources. RestClient is the default implementation; caches, fakes and other
+// backends can be slotted in by wrapping or replacing it in
+// RipeDbProviderData.
+//
+// An empty source means the default source of the client. Objects missing
+// from the database are reported with an error for which isNotFound is true.
+type RipeDbClient interface {
+	// GetSource returns the default source of the client.
+	GetSource() string
+	GetSkipValidation() bool
+	GetSkipUnknownKeys() bool
+
+	// GetObject looks up an object unfiltered, as the resources need its
+	// contact attributes to detect the drift.
+	GetObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error)
+	GetObjectWithOptions(ctx context.Context, source string, resource string, key string, unfiltered bool) (*rpsl.Object, error)
+	CreateObjectWithOptions(ctx context.Context, source string, resource string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error)
+	UpdateObjectWithOptions(ctx context.Context, source string, resource string, key string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error)
+	DeleteObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error)
+
+	// Search returns the objects matching the query, or no objects when
+	// nothing matches.
+	Search(ctx context.Context, opts SearchOptions) ([]*rpsl.Object, error)
+}
+
+// SearchOptions is a full-text or inverse query of the RIPE database. Empty
+// values are not sent.
+type SearchOptions struct {
+	QueryString string
+
+	// Sources defaults to the default source of the client.
+	Sources           []string
+	TypeFilters       []string
+	InverseAttributes []string
+
+	// Flags are the single-letter query flags, e.g. `r` for non-recursive
+	// queries or `B` for unfiltered results.
+	Flags []string
+
+	// Unfiltered requests the contact attributes of the objects and, for the
+	// maintainers authenticated by the credentials of the client, their
+	// `auth` values.
+	Unfiltered bool
+}
+
+// isNotFound reports whether the error is a backend not finding the requested
+// object.
+func isNotFound(err error) bool {
+	var reqErr *RequestError
+	var whoisErr *WhoisError
+	return (errors.As(err, &reqErr) && reqErr.StatusCode == http.StatusNotFound) ||
+		(errors.As(err, &whoisErr) && whoisErr.Code == whoisNoEntries)
+}
+
+// ObjectVersion is an entry of the history of an object.
+type ObjectVersion struct {
+	Revision  int64  `json:"revision"`
+	Date      string `json:"date"`
+	Operation string `json:"operation"`
+}
+
+// VersionsClient is implemented by the backends keeping the history of the
+// objects.
+type VersionsClient interface {
+	// GetVersions returns the versions of an object, oldest first.
+	GetVersions(ctx context.Context, source string, resource string, key string) ([]ObjectVersion, error)
+
+	// GetVersion returns an object as it was at the given revision, with
+	// the contact attributes and `auth` values when unfiltered.
+	GetVersion(ctx context.Context, source string, resource string, key string, revision int64, unfiltered bool) (*rpsl.Object, error)
+}
+
+// Ensure RestClient satisfies the VersionsClient interface.
+var _ VersionsClient = &RestClient{}
+
+// AbuseContact is the abuse mailbox responsible for a resource.
+type AbuseContact struct {
+	// Key is the nic-hdl of the role object holding the `abuse-mailbox`.
+	Key          string `json:"key"`
+	Email        string `json:"email"`
+	Suspect      bool   `json:"suspect"`
+	Organisation string `json:"org-id"`
+}
+
+// AbuseContactClient is implemented by the backends resolving the abuse
+// contact of the IP addresses and AS numbers.
+type AbuseContactClient interface {
+	// GetAbuseContact returns the abuse contact of an IP address, prefix,
+	// range or AS number. A resource without abuse contact is reported with
+	// an error for which isNotFound is true.
+	GetAbuseContact(ctx context.Context, resource string) (*AbuseContact, error)
+}
+
+// Ensure RestClient satisfies the AbuseContactClient interface.
+var _ AbuseContactClient = &RestClient{}
+
+// ClassTemplate is the template of a class, listing its attributes in order.
+type ClassTemplate struct {
+	Class      string
+	Attributes []TemplateAttribute
+}
+
+// TemplateAttribute is an attribute of a class template.
+type TemplateAttribute struct {
+	Name string
+
+	// Requirement is one of `mandatory`, `optional`, `conditional` or
+	// `generated`.
+	Requirement string
+
+	// Cardinality is either `single` or `multiple`.
+	Cardinality string
+
+	PrimaryKey bool
+	LookupKey  bool
+	InverseKey bool
+}
+
+// TemplateClient is implemented by the backends serving the class templates.
+type TemplateClient interface {
+	GetTemplate(ctx context.Context, class string) (*ClassTemplate, error)
+}
+
+// Ensure RestClient satisfies the TemplateClient interface.
+var _ TemplateClient = &RestClient{}
+
+// clientCapability returns the client as T, or adds an error to the
+// diagnostics when the backend does not implement the capability.
+func clientCapability[T any](client RipeDbClient, capability string, diags *diag.Diagnostics) (T, bool) {
+	c, ok := client.(T)
+	if !ok {
+		diags.AddError(
+			"Unsupported backend",
+			fmt.Sprintf("The %s are not available with the configured backend, use the `%s` backend instead.", capability, BackendRest),
+		)
+	}
+
+	return c, ok
+}
@@ -0,0 +1,72 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultAttributeModel describes an entry of the `default_attributes`
+// provider setting.
+type DefaultAttributeModel struct {
+	Name    types.String `tfsdk:"name"`
+	Value   types.String `tfsdk:"value"`
+	Classes types.List   `tfsdk:"classes"`
+}
+
+// DefaultAttribute is an attribute added to every managed object of the
+// given classes, or of every class when Classes is empty.
+type DefaultAttribute struct {
+	Name    string
+	Value   string
+	Classes []string
+}
+
+func (a DefaultAttribute) appliesTo(class string) bool {
+	return len(a.Classes) == 0 || slices.Contains(a.Classes, class)
+}
+
+// mergeDefaultAttributes appends the default attributes applicable to the
+// class after the explicit ones. An explicit attribute overrides all the
+// defaults with the same name, and duplicate defaults are only added once.
+func mergeDefaultAttributes(class string, attributes []ObjectModelAttribute, defaults []DefaultAttribute) []ObjectModelAttribute {
+	merged := slices.Clone(attributes)
+	for _, d := range defaults {
+		if !d.appliesTo(class) || hasAttributeNamed(attributes, d.Name) {
+			continue
+		}
+
+		attr := ObjectModelAttribute{Name: types.StringValue(d.Name), Value: types.StringValue(d.Value)}
+		if slices.Contains(merged, attr) {
+			continue
+		}
+
+		merged = append(merged, attr)
+	}
+
+	return merged
+}
+
+// removeDefaultAttributes is the inverse of mergeDefaultAttributes: it removes
+// from the attributes of an object the lines coming from the defaults, given
+// the attributes explicitly configured on it.
+func removeDefaultAttributes(class string, attributes []ObjectModelAttribute, configured []ObjectModelAttribute, defaults []DefaultAttribute) []ObjectModelAttribute {
+	return slices.DeleteFunc(slices.Clone(attributes), func(a ObjectModelAttribute) bool {
+		if hasAttributeNamed(configured, a.Name.ValueString()) {
+			return false
+		}
+
+		return slices.ContainsFunc(defaults, func(d DefaultAttribute) bool {
+			return d.appliesTo(class) && d.Name == a.Name.ValueString() && d.Value == a.Value.ValueString()
+		})
+	})
+}
+
+func hasAttributeNamed(attributes []ObjectModelAttribute, name string) bool {
+	return slices.ContainsFunc(attributes, func(a ObjectModelAttribute) bool {
+		return a.Name.ValueString() == name
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testAttributes(pairs ...string) []ObjectModelAttribute {
+	attributes := []ObjectModelAttribute{}
+	for i := 0; i < len(pairs); i += 2 {
+		attributes = append(attributes, ObjectModelAttribute{
+			Name:  types.StringValue(pairs[i]),
+			Value: types.StringValue(pairs[i+1]),
+		})
+	}
+
+	return attributes
+}
+
+func TestDefaultAttributes(t *testing.T) {
+	defaults := []DefaultAttribute{
+		{Name: "mnt-by", Value: "EXAMPLE-MNT"},
+		{Name: "notify", Value: "noc@example.com"},
+		{Name: "org", Value: "ORG-EXA1-RIPE", Classes: []string{"inetnum", "aut-num"}},
+		{Name: "mnt-by", Value: "EXAMPLE-MNT"},
+	}
+
+	configured := testAttributes("nic-hdl", "JS1-RIPE", "notify", "john@example.com")
+	merged := mergeDefaultAttributes("person", configured, defaults)
+	expected := testAttributes("nic-hdl", "JS1-RIPE", "notify", "john@example.com", "mnt-by", "EXAMPLE-MNT")
+	if !slices.Equal(merged, expected) {
+		t.Fatalf("expected %v, got %v", expected, merged)
+	}
+
+	merged = mergeDefaultAttributes("aut-num", testAttributes("as-name", "EXAMPLE"), defaults)
+	expected = testAttributes("as-name", "EXAMPLE", "mnt-by", "EXAMPLE-MNT", "notify", "noc@example.com", "org", "ORG-EXA1-RIPE")
+	if !slices.Equal(merged, expected) {
+		t.Fatalf("expected %v, got %v", expected, merged)
+	}
+
+	removed := removeDefaultAttributes("aut-num", merged, testAttributes("as-name", "EXAMPLE"), defaults)
+	expected = testAttributes("as-name", "EXAMPLE")
+	if !slices.Equal(removed, expected) {
+		t.Fatalf("expected %v, got %v", expected, removed)
+	}
+
+	removed = removeDefaultAttributes("person", testAttributes("nic-hdl", "JS1-RIPE", "mnt-by", "EXAMPLE-MNT"), testAttributes("nic-hdl", "JS1-RIPE", "mnt-by", "EXAMPLE-MNT"), defaults)
+	expected = testAttributes("nic-hdl", "JS1-RIPE", "mnt-by", "EXAMPLE-MNT")
+	if !slices.Equal(removed, expected) {
+		t.Fatalf("expected the explicit attributes to be kept, got %v", removed)
+	}
+}
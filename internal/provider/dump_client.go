@@ -0,0 +1,288 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/frederic-arr/rpsl-go"
+
+	"terraform-provider-ripedb/internal/iprange"
+)
+
+// Ensure DumpClient satisfies the RipeDbClient interface.
+var _ RipeDbClient = &DumpClient{}
+
+// dumpFilePrefix is the prefix of the RIPE split database dump files, named
+// `ripe.db.<class>.gz`.
+const dumpFilePrefix = "ripe.db."
+
+// errReadOnlyDump is returned by the write operations of the dump backend.
+var errReadOnlyDump = errors.New("the dump backend is read-only, objects cannot be created, updated or deleted")
+
+// errNotInDump is returned when an object is not in the dumps. It is a 404
+// RequestError, like the RIPE database not finding an object.
+var errNotInDump = &RequestError{StatusCode: http.StatusNotFound, Messages: []string{"ERROR:101: no entries found"}}
+
+// DumpClient serves lookups from the RIPE split database dumps found in a
+// directory, for audits and air-gapped runs. The dump of a class is loaded in
+// memory and indexed by primary key the first time an object of the class is
+// looked up, the searches stream the dumps of the classes which are not.
+type DumpClient struct {
+	path   string
+	source string
+
+	mu      sync.Mutex
+	classes map[string]map[string]*rpsl.Object
+}
+
+func NewDumpClient(path string, source string) (*DumpClient, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", path)
+	}
+
+	return &DumpClient{
+		path:    path,
+		source:  source,
+		classes: map[string]map[string]*rpsl.Object{},
+	}, nil
+}
+
+func (c *DumpClient) GetSource() string {
+	return c.source
+}
+
+func (c *DumpClient) GetSkipValidation() bool {
+	return false
+}
+
+func (c *DumpClient) GetSkipUnknownKeys() bool {
+	return false
+}
+
+func (c *DumpClient) GetObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error) {
+	if source != "" && !strings.EqualFold(source, c.source) {
+		return nil, errNotInDump
+	}
+
+	objects, err := c.load(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := objects[strings.ToUpper(key)]
+	if !ok {
+		return nil, errNotInDump
+	}
+
+	return &rpsl.Object{Attributes: slices.Clone(obj.Attributes)}, nil
+}
+
+// GetObjectWithOptions looks up an object. The dumps hold the filtered
+// objects, so unfiltered has no effect.
+func (c *DumpClient) GetObjectWithOptions(ctx context.Context, source string, resource string, key string, unfiltered bool) (*rpsl.Object, error) {
+	return c.GetObject(ctx, source, resource, key)
+}
+
+func (c *DumpClient) CreateObjectWithOptions(ctx context.Context, source string, resource string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error) {
+	return nil, errReadOnlyDump
+}
+
+func (c *DumpClient) UpdateObjectWithOptions(ctx context.Context, source string, resource string, key string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error) {
+	return nil, errReadOnlyDump
+}
+
+func (c *DumpClient) DeleteObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error) {
+	return nil, errReadOnlyDump
+}
+
+// Search matches the query against the primary keys of the objects, the
+// inverse attributes when set, or the address ranges with the less and more
+// specific flags. The dumps of the classes which are not loaded are streamed,
+// only the matching objects are kept in memory. Without type filters, every
+// dumped class is read.
+func (c *DumpClient) Search(ctx context.Context, opts SearchOptions) ([]*rpsl.Object, error) {
+	objects := []*rpsl.Object{}
+	if len(opts.Sources) > 0 && !slices.ContainsFunc(opts.Sources, func(s string) bool { return strings.EqualFold(s, c.source) }) {
+		return objects, nil
+	}
+
+	classes := opts.TypeFilters
+	if len(classes) == 0 {
+		var err error
+		if classes, err = c.dumpedClasses(); err != nil {
+			return nil, err
+		}
+	}
+
+	var match func(class string, obj *rpsl.Object) bool
+	var query iprange.Range
+	i := slices.IndexFunc(opts.Flags, func(f string) bool { return slices.Contains(iprange.HierarchyFlags, f) })
+	switch {
+	case i >= 0:
+		var err error
+		if query, err = iprange.Parse(opts.QueryString); err != nil {
+			return nil, err
+		}
+
+		// The candidates are the ranges containing or contained in the
+		// query, the hierarchy is then resolved among them.
+		classes = slices.DeleteFunc(slices.Clone(classes), func(class string) bool { return !slices.Contains(iprange.Classes, class) })
+		match = func(class string, obj *rpsl.Object) bool {
+			r, err := iprange.ObjectRange(obj)
+			return err == nil && (r.Contains(query) || query.Contains(r))
+		}
+	case len(opts.InverseAttributes) == 0:
+		match = func(class string, obj *rpsl.Object) bool {
+			return strings.EqualFold(objectKey(class, obj), opts.QueryString)
+		}
+	default:
+		match = func(class string, obj *rpsl.Object) bool {
+			return slices.ContainsFunc(obj.Attributes, func(a rpsl.Attribute) bool {
+				return slices.Contains(opts.InverseAttributes, a.Name) && strings.EqualFold(a.Value, opts.QueryString)
+			})
+		}
+	}
+
+	for _, class := range classes {
+		matches := []*rpsl.Object{}
+		err := c.scan(class, func(obj *rpsl.Object) {
+			if match(class, obj) {
+				matches = append(matches, obj)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		slices.SortFunc(matches, func(a, b *rpsl.Object) int {
+			return strings.Compare(strings.ToUpper(objectKey(class, a)), strings.ToUpper(objectKey(class, b)))
+		})
+
+		if i >= 0 {
+			matches = iprange.Hierarchy(query, matches, opts.Flags[i])
+		}
+
+		for _, obj := range matches {
+			objects = append(objects, &rpsl.Object{Attributes: slices.Clone(obj.Attributes)})
+		}
+	}
+
+	return objects, nil
+}
+
+// dumpedClasses returns the classes having a dump file.
+func (c *DumpClient) dumpedClasses() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(c.path, dumpFilePrefix+"*.gz"))
+	if err != nil {
+		return nil, err
+	}
+
+	classes := []string{}
+	for _, file := range files {
+		classes = append(classes, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), dumpFilePrefix), ".gz"))
+	}
+
+	return classes, nil
+}
+
+// load returns the objects of a class indexed by their upper-cased primary
+// key, reading the dump of the class on first use. A class without a dump
+// file has no objects.
+func (c *DumpClient) load(class string) (map[string]*rpsl.Object, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if objects, ok := c.classes[class]; ok {
+		return objects, nil
+	}
+
+	objects := map[string]*rpsl.Object{}
+	err := c.read(class, func(obj *rpsl.Object) {
+		objects[strings.ToUpper(objectKey(class, obj))] = obj
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.classes[class] = objects
+	return objects, nil
+}
+
+// scan calls fn with every object of a class, from memory when the class is
+// loaded, else streaming its dump without loading it.
+func (c *DumpClient) scan(class string, fn func(obj *rpsl.Object)) error {
+	c.mu.Lock()
+	objects, ok := c.classes[class]
+	c.mu.Unlock()
+	if !ok {
+		return c.read(class, fn)
+	}
+
+	for _, obj := range objects {
+		fn(obj)
+	}
+
+	return nil
+}
+
+// read streams the dump of a class, calling fn with every object of the
+// class. A class without a dump file has no objects.
+func (c *DumpClient) read(class string, fn func(obj *rpsl.Object)) error {
+	f, err := os.Open(filepath.Join(c.path, dumpFilePrefix+class+".gz"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read the %s dump: %w", class, err)
+	}
+	defer gz.Close()
+
+	reader := rpsl.NewReader(gz)
+	for {
+		obj, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to parse the %s dump: %w", class, err)
+		}
+
+		if len(obj.Attributes) == 0 || obj.Attributes[0].Name != class {
+			continue
+		}
+
+		// The dumps are filtered, their source reads e.g. `RIPE # Filtered`.
+		for i, a := range obj.Attributes {
+			if a.Name == "source" {
+				obj.Attributes[i].Value = strings.TrimSpace(strings.SplitN(a.Value, "#", 2)[0])
+			}
+		}
+
+		fn(&obj)
+	}
+}
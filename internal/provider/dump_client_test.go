@@ -0,0 +1,114 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTestDump(t *testing.T, dir string, class string, content string) {
+	f, err := os.Create(filepath.Join(dir, dumpFilePrefix+class+".gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDumpClient(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeTestDump(t, dir, "aut-num", `#
+# The contents of this file are subject to the RIPE Database Terms and Conditions
+#
+
+aut-num:        AS3333
+as-name:        RIPE-NCC-AS
+mnt-by:         RIPE-NCC-MNT
+source:         RIPE # Filtered
+
+aut-num:        AS2121
+as-name:        RIPE-MEETING-AS
+mnt-by:         RIPE-NCC-MNT
+source:         RIPE # Filtered
+`)
+	writeTestDump(t, dir, "route", `route:          193.0.0.0/21
+origin:         AS3333
+mnt-by:         RIPE-NCC-MNT
+source:         RIPE # Filtered
+`)
+
+	client, err := NewDumpClient(dir, DefaultSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, err := client.GetObject(ctx, "", "aut-num", "as3333")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if source := obj.GetFirst("source"); source == nil || *source != "RIPE" {
+		t.Errorf("expected the source to be unfiltered, got %v", obj)
+	}
+
+	if _, err := client.GetObject(ctx, "", "route", "193.0.0.0/21AS3333"); err != nil {
+		t.Errorf("expected the route to be found by its composite key, got %v", err)
+	}
+
+	for _, lookup := range [][3]string{{"", "aut-num", "AS1"}, {"", "person", "JS1-RIPE"}, {"ARIN-GRS", "aut-num", "AS3333"}} {
+		if _, err := client.GetObject(ctx, lookup[0], lookup[1], lookup[2]); !isNotFound(err) {
+			t.Errorf("expected %v not to be found, got %v", lookup, err)
+		}
+	}
+
+	objects, err := client.Search(ctx, SearchOptions{QueryString: "RIPE-NCC-MNT", InverseAttributes: []string{"mnt-by"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 3 {
+		t.Errorf("expected the 3 objects maintained by RIPE-NCC-MNT, got %d", len(objects))
+	}
+
+	objects, err = client.Search(ctx, SearchOptions{QueryString: "193.0.0.0/24", TypeFilters: []string{"route"}, Flags: []string{"r", "l"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 1 || objects[0].Attributes[0].Value != "193.0.0.0/21" {
+		t.Errorf("expected the less specific route, got %v", objects)
+	}
+
+	searchClient, err := NewDumpClient(dir, DefaultSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := searchClient.Search(ctx, SearchOptions{QueryString: "AS3333"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(searchClient.classes) != 0 {
+		t.Errorf("expected the searches not to load the dumps in memory, got %v", slices.Collect(maps.Keys(searchClient.classes)))
+	}
+
+	if _, err := client.DeleteObject(ctx, "", "aut-num", "AS3333"); err == nil {
+		t.Errorf("expected the dump backend to be read-only")
+	}
+}
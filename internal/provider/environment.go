@@ -0,0 +1,106 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/frederic-arr/rpsl-go"
+)
+
+const (
+	EnvironmentProd = "prod"
+	EnvironmentTest = "test"
+
+	DefaultTestEndpoint     = "https://rest-test.db.ripe.net"
+	DefaultTestMtlsEndpoint = "https://rest-cert-test.db.ripe.net"
+	DefaultTestSource       = "TEST"
+)
+
+// Environments lists the values accepted by the `environment` setting.
+var Environments = []string{EnvironmentProd, EnvironmentTest}
+
+// environmentDefaults returns the default endpoint, mTLS endpoint and source
+// of an environment.
+func environmentDefaults(environment string) (string, string, string) {
+	if environment == EnvironmentTest {
+		return DefaultTestEndpoint, DefaultTestMtlsEndpoint, DefaultTestSource
+	}
+
+	return DefaultEndpoint, DefaultMtlsEndpoint, DefaultSource
+}
+
+// The handle rewriting lets a configuration written for the production
+// database be applied to the TEST database: handles such as `JS1-RIPE` and
+// the `RIPE` source are sent as `JS1-TEST` and `TEST`, and the responses are
+// rewritten back so that the plan and state keep the production values.
+
+// handleRewriter rewrites the values sent to the TEST database, and rewrites
+// back every `-TEST` handle and the `TEST` source of the responses, whatever
+// was sent before, so that every run and data source sees the same values.
+// The handles genuinely named `FOO-TEST` in the TEST database are only left
+// untouched when they are listed in testHandles.
+type handleRewriter struct {
+	// testHandles are the upper-cased handles which are not rewritten back.
+	testHandles map[string]bool
+}
+
+func newHandleRewriter(testHandles []string) *handleRewriter {
+	r := &handleRewriter{testHandles: map[string]bool{}}
+	for _, handle := range testHandles {
+		r.testHandles[strings.ToUpper(handle)] = true
+	}
+
+	return r
+}
+
+// outgoing rewrites a production handle or source to its TEST database
+// equivalent.
+func (r *handleRewriter) outgoing(value string) string {
+	return toTestHandle(value)
+}
+
+// incoming rewrites a TEST handle or source returned by the database back to
+// its production equivalent, unless it is one of the test handles.
+func (r *handleRewriter) incoming(value string) string {
+	if r.testHandles[strings.ToUpper(value)] {
+		return value
+	}
+
+	return swapSuffix(value, DefaultTestSource, DefaultSource)
+}
+
+// toTestHandle rewrites a production handle or source to its TEST database
+// equivalent. Values which are not a single handle are left untouched.
+func toTestHandle(value string) string {
+	return swapSuffix(value, DefaultSource, DefaultTestSource)
+}
+
+func swapSuffix(value string, from string, to string) string {
+	if strings.EqualFold(value, from) {
+		return to
+	}
+
+	if strings.ContainsAny(value, " \t") {
+		return value
+	}
+
+	suffix := "-" + from
+	if len(value) > len(suffix) && strings.EqualFold(value[len(value)-len(suffix):], suffix) {
+		return value[:len(value)-len(suffix)] + "-" + to
+	}
+
+	return value
+}
+
+// rewriteObject returns a copy of the object with every value rewritten.
+func rewriteObject(obj *rpsl.Object, rewrite func(string) string) *rpsl.Object {
+	c := rpsl.Object{Attributes: slices.Clone(obj.Attributes)}
+	for i, a := range c.Attributes {
+		c.Attributes[i].Value = rewrite(a.Value)
+	}
+
+	return &c
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/frederic-arr/rpsl-go"
+)
+
+func TestHandleRewriting(t *testing.T) {
+	cases := map[string]string{
+		"JS1-RIPE":        "JS1-TEST",
+		"ORG-EXA1-RIPE":   "ORG-EXA1-TEST",
+		"RIPE":            "TEST",
+		"ripe":            "TEST",
+		"RIPE-NCC-HM-MNT": "RIPE-NCC-HM-MNT",
+		"EXAMPLE-MNT":     "EXAMPLE-MNT",
+		"Managed by RIPE": "Managed by RIPE",
+		"-RIPE":           "-RIPE",
+	}
+
+	for value, expected := range cases {
+		if actual := toTestHandle(value); actual != expected {
+			t.Errorf("toTestHandle(%q): expected %q, got %q", value, expected, actual)
+		}
+	}
+
+	obj := &rpsl.Object{Attributes: []rpsl.Attribute{
+		{Name: "person", Value: "John Smith"},
+		{Name: "nic-hdl", Value: "JS1-RIPE"},
+		{Name: "source", Value: "RIPE"},
+	}}
+
+	rewriter := newHandleRewriter([]string{"foo-test"})
+	roundTrip := rewriteObject(rewriteObject(obj, rewriter.outgoing), rewriter.incoming)
+	if roundTrip.String() != obj.String() {
+		t.Errorf("expected the rewriting to round trip, got %q", roundTrip.String())
+	}
+
+	// The values are rewritten back whether or not they were sent before.
+	for value, expected := range map[string]string{
+		"AB1-TEST":       "AB1-RIPE",
+		"TEST":           "RIPE",
+		"FOO-TEST":       "FOO-TEST",
+		"TEST-MNT":       "TEST-MNT",
+		"Rehearsal TEST": "Rehearsal TEST",
+	} {
+		if actual := newHandleRewriter([]string{"FOO-TEST"}).incoming(value); actual != expected {
+			t.Errorf("incoming(%q): expected %q, got %q", value, expected, actual)
+		}
+	}
+
+	if obj.Attributes[1].Value != "JS1-RIPE" {
+		t.Errorf("expected the original object to be left untouched")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-ripedb/internal/iprange"
+)
+
+var _ datasource.DataSource = &FreeSpaceDataSource{}
+
+// DefaultFreeSpaceLimit is the number of blocks returned by default when a
+// prefix length is requested, as an IPv6 allocation holds billions of them.
+const DefaultFreeSpaceLimit = 256
+
+func NewFreeSpaceDataSource() datasource.DataSource {
+	return &FreeSpaceDataSource{}
+}
+
+type FreeSpaceDataSource struct {
+	client RipeDbClient
+}
+
+type FreeSpaceDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Parent       types.String `tfsdk:"parent"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	Limit        types.Int64  `tfsdk:"limit"`
+	Source       types.String `tfsdk:"source"`
+	Free         types.List   `tfsdk:"free"`
+	FirstFree    types.String `tfsdk:"first_free"`
+}
+
+func (d *FreeSpaceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_free_space"
+}
+
+func (d *FreeSpaceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source returns the blocks of an address range not covered by any of its more specific `inetnum` or `inet6num` objects, e.g. to pick the next free block of an allocation for a new assignment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the parent range",
+				Computed:            true,
+			},
+			"parent": schema.StringAttribute{
+				MarkdownDescription: "the parent range, as a prefix or `first - last`, e.g. `192.0.2.0/24` or `2001:db8::/32`",
+				Required:            true,
+			},
+			"prefix_length": schema.Int64Attribute{
+				MarkdownDescription: "the length of the free blocks to return. The larger free blocks are split, the smaller ones are left out. Defaults to returning the largest free blocks",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("the maximum number of free blocks to return. Defaults to %d when `prefix_length` is set, and to all the free blocks otherwise", DefaultFreeSpaceLimit),
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the objects. Defaults to the `database` of the provider",
+				Optional:            true,
+			},
+			"free": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the free blocks, as prefixes in ascending order",
+				Computed:            true,
+			},
+			"first_free": schema.StringAttribute{
+				MarkdownDescription: "the first free block, unset when the parent range is full",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *FreeSpaceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *FreeSpaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data FreeSpaceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parent, err := iprange.Parse(data.Parent.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parent"), "Invalid range", err.Error())
+		return
+	}
+
+	if length := data.PrefixLength.ValueInt64(); !data.PrefixLength.IsNull() && (length < 0 || length > int64(parent.First.BitLen())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prefix_length"),
+			"Invalid prefix length",
+			fmt.Sprintf("The prefix length must be between 0 and %d, got %d.", parent.First.BitLen(), length),
+		)
+
+		return
+	}
+
+	if !data.Limit.IsNull() && data.Limit.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("limit"), "Invalid limit", "The limit must be at least 1.")
+		return
+	}
+
+	class := "inetnum"
+	if parent.First.Is6() {
+		class = "inet6num"
+	}
+
+	// The first level of more specific objects covers the nested ones.
+	opts := SearchOptions{
+		QueryString: data.Parent.ValueString(),
+		TypeFilters: []string{class},
+		Flags:       []string{"r", "m"},
+	}
+
+	if !data.Source.IsNull() {
+		opts.Sources = []string{data.Source.ValueString()}
+	}
+
+	objects, err := d.client.Search(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to search RIPE database", err.Error())
+		return
+	}
+
+	used := []iprange.Range{}
+	for _, obj := range objects {
+		if r, err := iprange.ObjectRange(obj); err == nil {
+			used = append(used, r)
+		}
+	}
+
+	bits := -1
+	limit := int(data.Limit.ValueInt64())
+	if !data.PrefixLength.IsNull() {
+		bits = int(data.PrefixLength.ValueInt64())
+		if data.Limit.IsNull() {
+			limit = DefaultFreeSpaceLimit
+		}
+	}
+
+	free := []string{}
+	for _, p := range freeBlocks(parent, used, bits, limit) {
+		free = append(free, p.String())
+	}
+
+	data.Id = data.Parent
+	data.FirstFree = types.StringNull()
+	if len(free) > 0 {
+		data.FirstFree = types.StringValue(free[0])
+	}
+
+	var diags diag.Diagnostics
+	data.Free, diags = types.ListValueFrom(ctx, types.StringType, free)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFreeSpaceDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	for _, obj := range []string{
+		"inetnum: 192.0.2.0 - 192.0.2.255\nstatus: ALLOCATED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.63\nstatus: ASSIGNED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.128 - 192.0.2.191\nstatus: ASSIGNED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.128 - 192.0.2.135\nstatus: ASSIGNED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_free_space" "test" {
+					parent = "192.0.2.0/24"
+				}
+
+				data "ripedb_free_space" "sized" {
+					parent        = "192.0.2.0/24"
+					prefix_length = 28
+					limit         = 3
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_free_space.test", "free.#", "2"),
+					resource.TestCheckResourceAttr("data.ripedb_free_space.test", "free.0", "192.0.2.64/26"),
+					resource.TestCheckResourceAttr("data.ripedb_free_space.test", "free.1", "192.0.2.192/26"),
+					resource.TestCheckResourceAttr("data.ripedb_free_space.sized", "free.#", "3"),
+					resource.TestCheckResourceAttr("data.ripedb_free_space.sized", "first_free", "192.0.2.64/28"),
+				),
+			},
+		},
+	})
+}
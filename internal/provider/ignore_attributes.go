@@ -0,0 +1,97 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+
+	"github.com/frederic-arr/rpsl-go"
+)
+
+// preservedAttributes reports which attributes of the current object are
+// managed outside of Terraform: those with an ignored name, except for the
+// values configured on the object, which are managed alongside them.
+func preservedAttributes(current []rpsl.Attribute, configured []rpsl.Attribute, ignored []string) []bool {
+	remaining := slices.Clone(configured)
+	preserved := make([]bool, len(current))
+	for i, a := range current {
+		if !slices.Contains(ignored, a.Name) {
+			continue
+		}
+
+		if j := slices.Index(remaining, a); j >= 0 {
+			remaining = slices.Delete(remaining, j, j+1)
+			continue
+		}
+
+		preserved[i] = true
+	}
+
+	return preserved
+}
+
+// mergeIgnoredAttributes returns the desired attributes with the preserved
+// attributes of the current object kept in place: each one follows the same
+// attribute as in the current object, so that updates do not reorder them.
+func mergeIgnoredAttributes(desired []rpsl.Attribute, current []rpsl.Attribute, ignored []string) []rpsl.Attribute {
+	preserved := preservedAttributes(current, desired, ignored)
+
+	// Pair the other attributes of the current object with the desired ones,
+	// the unchanged values first, then the changed values by name.
+	pairs := make([]int, len(current))
+	matched := make([]bool, len(desired))
+	for i := range pairs {
+		pairs[i] = -1
+	}
+
+	for _, same := range []func(a, b rpsl.Attribute) bool{
+		func(a, b rpsl.Attribute) bool { return a == b },
+		func(a, b rpsl.Attribute) bool { return a.Name == b.Name },
+	} {
+		for i, a := range current {
+			if preserved[i] || pairs[i] >= 0 {
+				continue
+			}
+
+			for j, d := range desired {
+				if !matched[j] && same(a, d) {
+					pairs[i], matched[j] = j, true
+					break
+				}
+			}
+		}
+	}
+
+	after := map[int][]rpsl.Attribute{}
+	anchor := -1
+	for i, a := range current {
+		if preserved[i] {
+			after[anchor] = append(after[anchor], a)
+		} else if pairs[i] >= 0 {
+			anchor = pairs[i]
+		}
+	}
+
+	merged := slices.Clone(after[-1])
+	for j, d := range desired {
+		merged = append(merged, d)
+		merged = append(merged, after[j]...)
+	}
+
+	return merged
+}
+
+// withoutPreservedAttributes returns a copy of the object without the
+// attributes managed outside of Terraform, given the configured attributes.
+func withoutPreservedAttributes(obj *rpsl.Object, configured []ObjectModelAttribute, ignored []string) *rpsl.Object {
+	preserved := preservedAttributes(obj.Attributes, modelToObject(&ObjectModel{Attributes: configured}).Attributes, ignored)
+	c := rpsl.Object{}
+	for i, a := range obj.Attributes {
+		if !preserved[i] {
+			c.Attributes = append(c.Attributes, a)
+		}
+	}
+
+	return &c
+}
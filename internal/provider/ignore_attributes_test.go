@@ -0,0 +1,28 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/frederic-arr/rpsl-go"
+)
+
+func TestMergeIgnoredAttributes(t *testing.T) {
+	current, _ := rpsl.Parse("aut-num: AS64496\nas-name: EXAMPLE\nremarks: managed by the registry\nremarks: ours\nmnt-by: EXAMPLE-MNT\nmnt-routes: CUSTOMER-MNT\nlast-modified: 2024-06-10T09:30:01Z\nsource: RIPE\n")
+	desired, _ := rpsl.Parse("aut-num: AS64496\nas-name: EXAMPLE-NEW\nremarks: ours\nmnt-by: EXAMPLE-MNT\nsource: RIPE\n")
+
+	merged := mergeIgnoredAttributes(desired.Attributes, current.Attributes, []string{"remarks", "mnt-routes"})
+	expected, _ := rpsl.Parse("aut-num: AS64496\nas-name: EXAMPLE-NEW\nremarks: managed by the registry\nremarks: ours\nmnt-by: EXAMPLE-MNT\nmnt-routes: CUSTOMER-MNT\nsource: RIPE\n")
+	if actual := (&rpsl.Object{Attributes: merged}).String(); actual != expected.String() {
+		t.Errorf("expected the ignored attributes to be kept in place, got %q", actual)
+	}
+
+	configured := testAttributes("as-name", "EXAMPLE", "remarks", "ours", "mnt-by", "EXAMPLE-MNT")
+	filtered := withoutPreservedAttributes(current, configured, []string{"remarks", "mnt-routes"})
+	expected, _ = rpsl.Parse("aut-num: AS64496\nas-name: EXAMPLE\nremarks: ours\nmnt-by: EXAMPLE-MNT\nlast-modified: 2024-06-10T09:30:01Z\nsource: RIPE\n")
+	if filtered.String() != expected.String() {
+		t.Errorf("expected only the configured ignored attributes to be kept, got %q", filtered.String())
+	}
+}
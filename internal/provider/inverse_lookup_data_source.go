@@ -0,0 +1,138 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &InverseLookupDataSource{}
+
+func NewInverseLookupDataSource() datasource.DataSource {
+	return &InverseLookupDataSource{}
+}
+
+type InverseLookupDataSource struct {
+	client RipeDbClient
+}
+
+type InverseLookupDataSourceModel struct {
+	Id                types.String  `tfsdk:"id"`
+	Handle            types.String  `tfsdk:"handle"`
+	InverseAttributes types.List    `tfsdk:"inverse_attributes"`
+	TypeFilter        types.List    `tfsdk:"type_filter"`
+	Source            types.String  `tfsdk:"source"`
+	Ids               types.List    `tfsdk:"ids"`
+	Objects           []ObjectModel `tfsdk:"objects"`
+}
+
+func (d *InverseLookupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inverse_lookup"
+}
+
+func (d *InverseLookupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source returns the objects referencing a handle in the RIPE Database, e.g. to check that nothing references a person or a maintainer before deleting it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the handle",
+				Computed:            true,
+			},
+			"handle": schema.StringAttribute{
+				MarkdownDescription: "the handle to look up, e.g. `JS1-RIPE` or `EXAMPLE-MNT`",
+				Required:            true,
+			},
+			"inverse_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the attributes referencing the handle, e.g. `admin-c`, `tech-c`, `mnt-by` or `org`",
+				Required:            true,
+			},
+			"type_filter": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the classes of the referencing objects to return. Defaults to all classes",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source to search. Defaults to the `database` of the provider",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the IDs of the referencing objects, in the `<class>:<primary_key>` format of the `ripedb_object` resource",
+				Computed:            true,
+			},
+			"objects": objectsAttribute("the referencing objects"),
+		},
+	}
+}
+
+func (d *InverseLookupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *InverseLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data InverseLookupDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The referenced objects, such as the persons of a maintainer, are not
+	// returned: only the objects referencing the handle are.
+	opts := SearchOptions{QueryString: data.Handle.ValueString(), Flags: []string{"r"}}
+	resp.Diagnostics.Append(listToStrings(ctx, data.InverseAttributes, &opts.InverseAttributes)...)
+	resp.Diagnostics.Append(listToStrings(ctx, data.TypeFilter, &opts.TypeFilters)...)
+	if !data.Source.IsNull() {
+		opts.Sources = []string{data.Source.ValueString()}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objects, err := d.client.Search(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to search RIPE database", err.Error())
+		return
+	}
+
+	data.Id = data.Handle
+	data.Objects = objectsToModels(objects)
+
+	ids := []string{}
+	for _, o := range data.Objects {
+		ids = append(ids, o.Id.ValueString())
+	}
+
+	var diags diag.Diagnostics
+	data.Ids, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccInverseLookupDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	for _, obj := range []string{
+		"person: John Smith\nnic-hdl: JS1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"role: Operations\nnic-hdl: OPS1-TEST\nadmin-c: JS1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"aut-num: AS64496\nas-name: EXAMPLE\ntech-c: JS1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_inverse_lookup" "test" {
+					handle             = "JS1-TEST"
+					inverse_attributes = ["admin-c", "tech-c"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_inverse_lookup.test", "ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.ripedb_inverse_lookup.test", "ids.*", "role:OPS1-TEST"),
+					resource.TestCheckTypeSetElemAttr("data.ripedb_inverse_lookup.test", "ids.*", "aut-num:AS64496"),
+					resource.TestCheckResourceAttr("data.ripedb_inverse_lookup.test", "objects.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LimitOptions configures the request budget shared by every resource and
+// data source using the provider. Zero values disable the limit.
+type LimitOptions struct {
+	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+}
+
+// limitTransport bounds the number of in-flight requests and spaces them out
+// so that the RIPE database does not rate-limit or block the source IP.
+// Every attempt of a retried request goes through the limiter.
+type limitTransport struct {
+	next    http.RoundTripper
+	limiter *limiter
+}
+
+func newLimitTransport(next http.RoundTripper, opts LimitOptions) http.RoundTripper {
+	l := newLimiter(opts)
+	if l == nil {
+		return next
+	}
+
+	return &limitTransport{next: next, limiter: l}
+}
+
+// RoundTrip holds the concurrency slot of the request until its response body
+// is closed, so that the slot bounds the transfers and not only the headers.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: sync.OnceFunc(release)}
+	return resp, nil
+}
+
+// releaseBody releases the concurrency slot of a request when its response
+// body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// limiter holds the request budget of a client. A nil limiter is unbounded.
+type limiter struct {
+	// slots holds one token per in-flight request, nil when unbounded.
+	slots chan struct{}
+
+	mu       sync.Mutex
+	interval time.Duration
+	nextSlot time.Time
+}
+
+// newLimiter returns the limiter for the options, or nil when they disable
+// every limit.
+func newLimiter(opts LimitOptions) *limiter {
+	if opts.MaxConcurrentRequests <= 0 && opts.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	l := &limiter{}
+	if opts.MaxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, opts.MaxConcurrentRequests)
+	}
+
+	if opts.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / opts.RequestsPerSecond)
+	}
+
+	return l
+}
+
+// acquire blocks until a request is allowed by the limits, and returns the
+// function releasing its concurrency slot.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := l.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// wait blocks until the request is allowed by the rate limit. Requests are
+// scheduled one interval apart, in the order they arrived.
+func (l *limiter) wait(ctx context.Context) error {
+	if l.interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.nextSlot.Before(now) {
+		l.nextSlot = now
+	}
+
+	delay := l.nextSlot.Sub(now)
+	l.nextSlot = l.nextSlot.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
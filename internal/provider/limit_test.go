@@ -0,0 +1,114 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitTransport_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, LimitOptions{MaxConcurrentRequests: 2})}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak.Load())
+	}
+}
+
+func TestLimitTransport_MaxConcurrentRequestsUntilBodyClosed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, LimitOptions{MaxConcurrentRequests: 1})}
+
+	first, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("expected the second request to wait for the body of the first one to be closed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	first.Body.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the second request to be sent once the body of the first one is closed")
+	}
+}
+
+func TestLimitTransport_RequestsPerSecond(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, LimitOptions{RequestsPerSecond: 50})}
+
+	start := time.Now()
+	for range 5 {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	// The first request goes through immediately, the four others are
+	// spaced 20ms apart.
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("expected the requests to take at least 80ms, took %s", elapsed)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/frederic-arr/ripedb-go/ripedb/models"
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem used for the RIPE database API calls.
+// Its level can be set independently with TF_LOG_PROVIDER_RIPEDB_API.
+const logSubsystem = "api"
+
+const redacted = "<redacted>"
+
+// redactedAttributes are the RPSL attributes holding credentials or
+// certificate material, whose values are never logged.
+var redactedAttributes = []string{"auth", "certif"}
+
+// redactedQueryParameters are the query parameters holding credentials.
+var redactedQueryParameters = []string{"password", "override"}
+
+// newLogContext returns a context logging to the API subsystem, which masks
+// the given secrets wherever they appear in messages and fields.
+func newLogContext(ctx context.Context, secrets ...string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, "authorization", "api_key", "password")
+
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, secret)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, secret)
+	}
+
+	return ctx
+}
+
+// redactURL returns the URL with its user info and credential query
+// parameters masked.
+func redactURL(u *url.URL) string {
+	c := *u
+	q := c.Query()
+	for _, name := range redactedQueryParameters {
+		if q.Has(name) {
+			q.Set(name, redacted)
+		}
+	}
+
+	c.RawQuery = q.Encode()
+	return c.Redacted()
+}
+
+// redactObject returns a copy of the object with the values of the sensitive
+// attributes masked.
+func redactObject(obj *rpsl.Object) *rpsl.Object {
+	c := rpsl.Object{Attributes: slices.Clone(obj.Attributes)}
+	for i, a := range c.Attributes {
+		if slices.Contains(redactedAttributes, a.Name) {
+			c.Attributes[i].Value = redacted
+		}
+	}
+
+	return &c
+}
+
+// redactedRpsl returns the redacted RPSL text of all the objects in a request
+// or response body.
+func redactedRpsl(res *models.Resource) string {
+	if res == nil || res.Objects == nil {
+		return ""
+	}
+
+	objects := []string{}
+	for i := range res.Objects.Object {
+		obj, err := models.ModelObjectToRpslObject(&res.Objects.Object[i])
+		if err != nil {
+			continue
+		}
+
+		objects = append(objects, redactObject(obj).String())
+	}
+
+	return strings.Join(objects, "\n\n")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRestClient_LogsAreRedacted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"objects": {"object": [{"attributes": {"attribute": [
+				{"name": "mntner", "value": "TEST-MNT"},
+				{"name": "auth", "value": "MD5-PW $1$secret-hash"},
+				{"name": "source", "value": "TEST"}
+			]}}]},
+			"errormessages": {"errormessage": [
+				{"severity": "Warning", "text": "Deprecated attribute \"%s\"", "args": [{"value": "changed"}]}
+			]}
+		}`))
+	}))
+	defer srv.Close()
+
+	// "TEST-MNT:hunter2" in base64.
+	apiKey := "VEVTVC1NTlQ6aHVudGVyMg=="
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, ApiKey: &apiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	if _, err := client.GetObject(ctx, "", "mntner", "TEST-MNT"); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{apiKey, "hunter2", "secret-hash"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("expected %q to be redacted from the logs", secret)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := map[string]map[string]interface{}{}
+	for _, entry := range entries {
+		message, _ := entry["@message"].(string)
+		messages[message] = entry
+	}
+
+	if _, ok := messages[`Deprecated attribute "changed"`]; !ok {
+		t.Errorf("expected the warning message to be logged")
+	}
+
+	response, ok := messages["RIPE database response body"]
+	if !ok {
+		t.Fatalf("expected the response body to be logged")
+	}
+
+	if body, _ := response["body"].(string); !strings.Contains(body, "auth:<redacted>") {
+		t.Errorf("expected the auth attribute to be redacted, got %q", body)
+	}
+}
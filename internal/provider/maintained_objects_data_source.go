@@ -0,0 +1,171 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &MaintainedObjectsDataSource{}
+
+// maintainerAttributes are the attributes through which a maintainer
+// protects objects.
+var maintainerAttributes = []string{"mnt-by", "mnt-lower", "mnt-routes", "mnt-domains"}
+
+func NewMaintainedObjectsDataSource() datasource.DataSource {
+	return &MaintainedObjectsDataSource{}
+}
+
+type MaintainedObjectsDataSource struct {
+	client RipeDbClient
+}
+
+type MaintainedObjectsDataSourceModel struct {
+	Id                   types.String  `tfsdk:"id"`
+	Mntner               types.String  `tfsdk:"mntner"`
+	MaintainerAttributes types.List    `tfsdk:"maintainer_attributes"`
+	TypeFilter           types.List    `tfsdk:"type_filter"`
+	Source               types.String  `tfsdk:"source"`
+	Ids                  types.List    `tfsdk:"ids"`
+	IdsByClass           types.Map     `tfsdk:"ids_by_class"`
+	Objects              []ObjectModel `tfsdk:"objects"`
+}
+
+func (d *MaintainedObjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_maintained_objects"
+}
+
+func (d *MaintainedObjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source returns every object protected by a maintainer in the RIPE Database, e.g. for compliance reports or to import the objects of a maintainer with `import` blocks.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the maintainer",
+				Computed:            true,
+			},
+			"mntner": schema.StringAttribute{
+				MarkdownDescription: "the maintainer, e.g. `EXAMPLE-MNT`",
+				Required:            true,
+			},
+			"maintainer_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("the attributes through which the objects are maintained, among `%s`. Defaults to `[\"mnt-by\"]`", strings.Join(maintainerAttributes, "`, `")),
+				Optional:            true,
+			},
+			"type_filter": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the classes of the objects to return. Defaults to all classes",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the objects. Defaults to the `database` of the provider",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the IDs of the objects, as `class:key`, which is also the import ID of the `ripedb_object` resource",
+				Computed:            true,
+			},
+			"ids_by_class": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "the IDs of the objects, grouped by class",
+				Computed:            true,
+			},
+			"objects": objectsAttribute("the maintained objects"),
+		},
+	}
+}
+
+func (d *MaintainedObjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *MaintainedObjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data MaintainedObjectsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := SearchOptions{QueryString: data.Mntner.ValueString(), InverseAttributes: []string{"mnt-by"}, Flags: []string{"r"}}
+	resp.Diagnostics.Append(listToStrings(ctx, data.MaintainerAttributes, &opts.InverseAttributes)...)
+	resp.Diagnostics.Append(listToStrings(ctx, data.TypeFilter, &opts.TypeFilters)...)
+	if !data.Source.IsNull() {
+		opts.Sources = []string{data.Source.ValueString()}
+	}
+
+	for i, attribute := range opts.InverseAttributes {
+		if !slices.Contains(maintainerAttributes, attribute) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("maintainer_attributes").AtListIndex(i),
+				"Invalid maintainer attribute",
+				fmt.Sprintf("The maintainer attributes must be among %s, got %q.", strings.Join(maintainerAttributes, ", "), attribute),
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objects, err := d.client.Search(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to search RIPE database", err.Error())
+		return
+	}
+
+	data.Id = data.Mntner
+	data.Objects = []ObjectModel{}
+
+	// An object maintained through several attributes is returned once per
+	// attribute.
+	ids := []string{}
+	idsByClass := map[string][]string{}
+	for _, o := range objectsToModels(objects) {
+		id := o.Id.ValueString()
+		if slices.Contains(ids, id) {
+			continue
+		}
+
+		data.Objects = append(data.Objects, o)
+		ids = append(ids, id)
+		idsByClass[o.Class.ValueString()] = append(idsByClass[o.Class.ValueString()], id)
+	}
+
+	var diags diag.Diagnostics
+	data.Ids, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.IdsByClass, diags = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, idsByClass)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMaintainedObjectsDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	for _, obj := range []string{
+		"person: John Smith\nnic-hdl: JS1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"aut-num: AS64496\nas-name: EXAMPLE\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.255\nmnt-by: OTHER-MNT\nmnt-lower: TEST-MNT\nsource: TEST\n",
+		"person: Jane Doe\nnic-hdl: JD1-TEST\nmnt-by: OTHER-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_maintained_objects" "test" {
+					mntner = "TEST-MNT"
+				}
+
+				data "ripedb_maintained_objects" "lower" {
+					mntner                = "TEST-MNT"
+					maintainer_attributes = ["mnt-by", "mnt-lower"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_maintained_objects.test", "ids.#", "3"),
+					resource.TestCheckTypeSetElemAttr("data.ripedb_maintained_objects.test", "ids.*", "mntner:TEST-MNT"),
+					resource.TestCheckTypeSetElemAttr("data.ripedb_maintained_objects.test", "ids.*", "person:JS1-TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_maintained_objects.test", "ids_by_class.aut-num.0", "aut-num:AS64496"),
+					resource.TestCheckResourceAttr("data.ripedb_maintained_objects.lower", "ids.#", "4"),
+					resource.TestCheckResourceAttr("data.ripedb_maintained_objects.lower", "ids_by_class.inetnum.0", "inetnum:192.0.2.0 - 192.0.2.255"),
+				),
+			},
+		},
+	})
+}
+
+func TestMaintainedObjectsDataSource_Duplicates(t *testing.T) {
+	client := &unsortedSearchClient{}
+	for _, text := range []string{
+		"inetnum: 192.0.2.0 - 192.0.2.255\nmnt-by: TEST-MNT\nmnt-lower: TEST-MNT\nsource: TEST\n",
+		"person: John Smith\nnic-hdl: JS1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.255\nmnt-by: TEST-MNT\nmnt-lower: TEST-MNT\nsource: TEST\n",
+	} {
+		obj, err := rpsl.Parse(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client.objects = append(client.objects, obj)
+	}
+
+	state := testReadDataSource(t, NewMaintainedObjectsDataSource(), client, map[string]tftypes.Value{
+		"mntner": tftypes.NewValue(tftypes.String, "TEST-MNT"),
+	})
+
+	var data MaintainedObjectsDataSourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if len(data.Objects) != 2 || len(data.Ids.Elements()) != 2 {
+		t.Errorf("expected 2 objects and ids, got %d objects and %v", len(data.Objects), data.Ids)
+	}
+}
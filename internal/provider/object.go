@@ -4,18 +4,32 @@
 package provider
 
 import (
+	"fmt"
 	"slices"
+	"strings"
 
+	"github.com/frederic-arr/ripedb-go/ripedb/models"
 	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var OMIT_KEYS = []string{"source", "created", "last-modified"}
 
+// objectAttributeType is the type of an element of an attributes list.
+var objectAttributeType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":  types.StringType,
+		"value": types.StringType,
+	},
+}
+
 type ObjectModel struct {
 	Id         types.String           `tfsdk:"id"`
 	Class      types.String           `tfsdk:"class"`
 	Value      types.String           `tfsdk:"value"`
+	Source     types.String           `tfsdk:"source"`
 	Attributes []ObjectModelAttribute `tfsdk:"attributes"`
 }
 
@@ -24,6 +38,18 @@ type ObjectModelAttribute struct {
 	Value types.String `tfsdk:"value"`
 }
 
+// UnfilteredObjectModel is an object returned by an unfiltered lookup, with
+// the values of its sensitive attributes.
+type UnfilteredObjectModel struct {
+	ObjectModel
+	SensitiveAttributes []ObjectModelAttribute `tfsdk:"sensitive_attributes"`
+}
+
+// sensitiveAttributes are the attributes hidden from the filtered objects,
+// whose values are masked in the objects returned unfiltered and only set in
+// their sensitive attributes.
+var sensitiveAttributes = []string{"auth", "e-mail", "notify", "changed", "upd-to", "mnt-nfy", "ref-nfy", "irt-nfy"}
+
 func objectToModel(obj *rpsl.Object, data *ObjectModel) {
 	data.Attributes = []ObjectModelAttribute{}
 	for _, a := range obj.Attributes {
@@ -49,10 +75,180 @@ func modelToObject(data *ObjectModel) *rpsl.Object {
 	return &obj
 }
 
-func filterObject(obj *rpsl.Object, data *ObjectModel) {
+// objectKey returns the primary key of an object, as used in the object IDs
+// and the lookup URLs.
+func objectKey(class string, obj *rpsl.Object) string {
+	if m := models.ObjectToModelUnchecked(class, *obj); m != nil {
+		return m.Key()
+	}
+
+	return obj.Attributes[0].Value
+}
+
+// objectsToModels returns the models of the objects returned by a search.
+func objectsToModels(objects []*rpsl.Object) []ObjectModel {
+	result := []ObjectModel{}
+	for _, obj := range objects {
+		class := obj.Attributes[0].Name
+		key := objectKey(class, obj)
+		data := ObjectModel{
+			Id:     types.StringValue(fmt.Sprintf("%s:%s", class, key)),
+			Class:  types.StringValue(class),
+			Value:  types.StringValue(key),
+			Source: types.StringPointerValue(obj.GetFirst("source")),
+		}
+
+		objectToModel(obj, &data)
+		result = append(result, data)
+	}
+
+	return result
+}
+
+// maskSensitive returns a copy of the object with the values of the sensitive
+// attributes masked, and those attributes with their values.
+func maskSensitive(obj *rpsl.Object) (*rpsl.Object, []ObjectModelAttribute) {
+	c := rpsl.Object{Attributes: slices.Clone(obj.Attributes)}
+	sensitive := []ObjectModelAttribute{}
+	for i, a := range c.Attributes {
+		if slices.Contains(sensitiveAttributes, a.Name) {
+			sensitive = append(sensitive, ObjectModelAttribute{
+				Name:  types.StringValue(a.Name),
+				Value: types.StringValue(a.Value),
+			})
+			c.Attributes[i].Value = redacted
+		}
+	}
+
+	return &c, sensitive
+}
+
+// masksSensitive reports whether the sensitive values of the objects are
+// masked: they are when the objects are requested unfiltered, unless
+// mask_sensitive is false.
+func masksSensitive(unfiltered types.Bool, mask types.Bool) bool {
+	return unfiltered.ValueBool() && (mask.IsNull() || mask.ValueBool())
+}
+
+// unfilteredObjectsToModels returns the models of the objects returned by a
+// search, with the values of their sensitive attributes masked when mask is
+// set.
+func unfilteredObjectsToModels(objects []*rpsl.Object, mask bool) []UnfilteredObjectModel {
+	result := []UnfilteredObjectModel{}
+	for _, obj := range objects {
+		sensitive := []ObjectModelAttribute{}
+		if mask {
+			obj, sensitive = maskSensitive(obj)
+		}
+
+		result = append(result, UnfilteredObjectModel{
+			ObjectModel:         objectsToModels([]*rpsl.Object{obj})[0],
+			SensitiveAttributes: sensitive,
+		})
+	}
+
+	return result
+}
+
+// unfilteredAttribute returns the schema of the option requesting the
+// objects unfiltered.
+func unfilteredAttribute() dschema.BoolAttribute {
+	return dschema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("whether to request the objects unfiltered, with the credentials of the provider: the contact attributes, e.g. `e-mail`, are returned, and so are the `auth` values of the maintainers authenticated by the credentials. The values of the `%s` attributes are then masked in the other attributes and only set in `sensitive_attributes`, unless `mask_sensitive` is `false`. Defaults to `false`", strings.Join(sensitiveAttributes, "`, `")),
+		Optional:            true,
+	}
+}
+
+// maskSensitiveAttribute returns the schema of the option keeping the
+// sensitive values of the unfiltered objects in their attributes.
+func maskSensitiveAttribute() dschema.BoolAttribute {
+	return dschema.BoolAttribute{
+		MarkdownDescription: "whether to mask the sensitive values of the objects requested `unfiltered` and only set them in `sensitive_attributes`. Set to `false` to keep them in the other attributes, which are not marked sensitive. Defaults to `true`",
+		Optional:            true,
+	}
+}
+
+// sensitiveAttributesAttribute returns the schema of the sensitive attributes
+// of an object returned unfiltered.
+func sensitiveAttributesAttribute() dschema.ListNestedAttribute {
+	return dschema.ListNestedAttribute{
+		MarkdownDescription: "the attributes of the object whose values are masked in the other attributes, with their values. Empty unless `unfiltered` is set and `mask_sensitive` is not `false`",
+		Computed:            true,
+		Sensitive:           true,
+		NestedObject: dschema.NestedAttributeObject{
+			Attributes: map[string]dschema.Attribute{
+				"name": dschema.StringAttribute{
+					MarkdownDescription: "the name of the attribute",
+					Computed:            true,
+				},
+				"value": dschema.StringAttribute{
+					MarkdownDescription: "the value of the attribute",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// objectsAttribute returns the schema of a computed list of objects, see
+// objectNestedAttributeObject.
+func objectsAttribute(description string) dschema.ListNestedAttribute {
+	return dschema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject:        objectNestedAttributeObject(),
+	}
+}
+
+// objectNestedAttributeObject returns the schema of a computed object, with
+// the `id`, `class`, `value`, `source` and `attributes` of the `ripedb_object`
+// data source. Its `rpsl`, `attributes_map`, `primary_key`, `created` and
+// `last_modified` are not set on the nested objects.
+func objectNestedAttributeObject() dschema.NestedAttributeObject {
+	return dschema.NestedAttributeObject{
+		Attributes: map[string]dschema.Attribute{
+			"id": dschema.StringAttribute{
+				MarkdownDescription: "the ID of the object",
+				Computed:            true,
+			},
+			"class": dschema.StringAttribute{
+				MarkdownDescription: "the class of the object",
+				Computed:            true,
+			},
+			"value": dschema.StringAttribute{
+				MarkdownDescription: "the key of the object",
+				Computed:            true,
+			},
+			"source": dschema.StringAttribute{
+				MarkdownDescription: "the source of the object",
+				Computed:            true,
+			},
+			"attributes": dschema.ListNestedAttribute{
+				MarkdownDescription: "the attributes of the object",
+				Computed:            true,
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: map[string]dschema.Attribute{
+						"name": dschema.StringAttribute{
+							MarkdownDescription: "the name of the attribute",
+							Computed:            true,
+						},
+						"value": dschema.StringAttribute{
+							MarkdownDescription: "the value of the attribute",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// filterObject sets the attributes of the model to those of the object, except
+// for its first attribute and the omitted ones.
+func filterObject(obj *rpsl.Object, data *ObjectModel, omitKeys []string) {
 	data.Attributes = []ObjectModelAttribute{}
 	for i, a := range obj.Attributes {
-		if i == 0 || slices.Contains(OMIT_KEYS, a.Name) {
+		if i == 0 || slices.Contains(omitKeys, a.Name) {
 			continue
 		}
 
@@ -62,3 +258,15 @@ func filterObject(obj *rpsl.Object, data *ObjectModel) {
 		})
 	}
 }
+
+// isGrsSource reports whether the source is a read-only mirror of another
+// registry, either by its name or because it is listed in `grs_sources`.
+func isGrsSource(source string, grsSources []string) bool {
+	if strings.HasSuffix(strings.ToUpper(source), "-GRS") {
+		return true
+	}
+
+	return slices.ContainsFunc(grsSources, func(s string) bool {
+		return strings.EqualFold(s, source)
+	})
+}
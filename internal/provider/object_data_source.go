@@ -7,10 +7,10 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/frederic-arr/ripedb-go/ripedb"
-	"github.com/frederic-arr/ripedb-go/ripedb/models"
+	"github.com/frederic-arr/rpsl-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,7 +21,23 @@ func NewObjectDataSource() datasource.DataSource {
 }
 
 type ObjectDataSource struct {
-	client *ripedb.RipeClient
+	client     RipeDbClient
+	grsSources []string
+}
+
+type ObjectDataSourceModel struct {
+	ObjectModel
+	AllowMissing  types.Bool   `tfsdk:"allow_missing"`
+	Found         types.Bool   `tfsdk:"found"`
+	Rpsl          types.String `tfsdk:"rpsl"`
+	AttributesMap types.Map    `tfsdk:"attributes_map"`
+	PrimaryKey    types.String `tfsdk:"primary_key"`
+	Created       types.String `tfsdk:"created"`
+	LastModified  types.String `tfsdk:"last_modified"`
+	Unfiltered    types.Bool   `tfsdk:"unfiltered"`
+	MaskSensitive types.Bool   `tfsdk:"mask_sensitive"`
+
+	SensitiveAttributes []ObjectModelAttribute `tfsdk:"sensitive_attributes"`
 }
 
 func (d *ObjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -44,6 +60,11 @@ func (d *ObjectDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "the key of the object",
 				Required:            true,
 			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the object. When unset, the object is looked up in the `database` of the provider, then in its `grs_sources`",
+				Optional:            true,
+				Computed:            true,
+			},
 			"attributes": schema.ListNestedAttribute{
 				MarkdownDescription: "the attributes of the object",
 				Computed:            true,
@@ -60,6 +81,38 @@ func (d *ObjectDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 					},
 				},
 			},
+			"allow_missing": schema.BoolAttribute{
+				MarkdownDescription: "whether to set `found` to `false` and leave the attributes of the object null when the object does not exist, rather than failing. Authentication and network errors still fail. Defaults to `false`",
+				Optional:            true,
+			},
+			"found": schema.BoolAttribute{
+				MarkdownDescription: "whether the object exists, always `true` unless `allow_missing` is set",
+				Computed:            true,
+			},
+			"rpsl": schema.StringAttribute{
+				MarkdownDescription: "the object in RPSL, one `name:value` line per attribute",
+				Computed:            true,
+			},
+			"attributes_map": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "the values of the attributes of the object, by name and in order",
+				Computed:            true,
+			},
+			"primary_key": schema.StringAttribute{
+				MarkdownDescription: "the primary key of the object, e.g. `192.0.2.0/24AS64496` for a route",
+				Computed:            true,
+			},
+			"created": schema.StringAttribute{
+				MarkdownDescription: "the creation date of the object",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "the date of the last change of the object",
+				Computed:            true,
+			},
+			"unfiltered":           unfilteredAttribute(),
+			"mask_sensitive":       maskSensitiveAttribute(),
+			"sensitive_attributes": sensitiveAttributesAttribute(),
 		},
 	}
 }
@@ -81,10 +134,15 @@ func (d *ObjectDataSource) Configure(ctx context.Context, req datasource.Configu
 	}
 
 	d.client = data.Client
+	d.grsSources = data.GrsSources
 }
 
 func (d *ObjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data ObjectModel
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data ObjectDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -93,14 +151,72 @@ func (d *ObjectDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	resource := data.Class.ValueString()
 	key := data.Value.ValueString()
-	obj, err := (*d.client).GetObject(resource, key)
+
+	sources := append([]string{d.client.GetSource()}, d.grsSources...)
+	if !data.Source.IsNull() {
+		sources = []string{data.Source.ValueString()}
+	}
+
+	obj, source, err := lookupObject(ctx, d.client, sources, resource, key, data.Unfiltered.ValueBool())
+	if isNotFound(err) && data.AllowMissing.ValueBool() {
+		data.Id = types.StringValue(fmt.Sprintf("%s:%s", resource, key))
+		data.Found = types.BoolValue(false)
+		data.Attributes = nil
+		data.Rpsl = types.StringNull()
+		data.AttributesMap = types.MapNull(types.ListType{ElemType: types.StringType})
+		data.PrimaryKey = types.StringNull()
+		data.Created = types.StringNull()
+		data.LastModified = types.StringNull()
+		data.SensitiveAttributes = nil
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("failed to query RIPE database", err.Error())
 		return
 	}
 
-	m := models.ObjectToModelUnchecked(resource, *obj)
-	objectToModel(obj, &data)
-	data.Id = types.StringValue(fmt.Sprintf("%s:%s", resource, m.Key()))
+	data.Source = types.StringValue(source)
+	data.Found = types.BoolValue(true)
+	data.SensitiveAttributes = []ObjectModelAttribute{}
+	if masksSensitive(data.Unfiltered, data.MaskSensitive) {
+		obj, data.SensitiveAttributes = maskSensitive(obj)
+	}
+
+	primaryKey := objectKey(resource, obj)
+	objectToModel(obj, &data.ObjectModel)
+	data.Id = types.StringValue(fmt.Sprintf("%s:%s", resource, primaryKey))
+	data.Rpsl = types.StringValue(obj.String())
+	data.PrimaryKey = types.StringValue(primaryKey)
+	data.Created = types.StringPointerValue(obj.GetFirst("created"))
+	data.LastModified = types.StringPointerValue(obj.GetFirst("last-modified"))
+
+	attributes := map[string][]string{}
+	for _, name := range obj.Keys() {
+		attributes[name] = obj.GetAll(name)
+	}
+
+	var diags diag.Diagnostics
+	data.AttributesMap, diags = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, attributes)
+	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// lookupObject returns an object from the first of the sources holding it,
+// with that source. The error of the last source is returned when no source
+// holds the object.
+func lookupObject(ctx context.Context, client RipeDbClient, sources []string, resource string, key string, unfiltered bool) (*rpsl.Object, string, error) {
+	var err error
+	for _, source := range sources {
+		var obj *rpsl.Object
+		obj, err = client.GetObjectWithOptions(ctx, source, resource, key, unfiltered)
+		if isNotFound(err) {
+			continue
+		}
+
+		return obj, source, err
+	}
+
+	return nil, "", err
+}
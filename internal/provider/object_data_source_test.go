@@ -4,18 +4,27 @@
 package provider
 
 import (
+	"context"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccObjectDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	if err := srv.AddObject(DefaultTestSource, "aut-num: AS3333\nas-name: RIPE-NCC-AS\nmnt-by: TEST-MNT\nmnt-by: OTHER-MNT\ncreated: 2002-09-19T15:12:08Z\nlast-modified: 2024-06-10T09:30:01Z\nsource: TEST\n"); err != nil {
+		t.Fatal(err)
+	}
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: `
+				Config: testAccProviderConfig(srv) + `
 				data "ripedb_object" "test" {
 					class = "aut-num"
 					value = "AS3333"
@@ -23,10 +32,192 @@ func TestAccObjectDataSource(t *testing.T) {
 				`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.ripedb_object.test", "id", "aut-num:AS3333"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "source", DefaultTestSource),
 					resource.TestCheckResourceAttr("data.ripedb_object.test", "attributes.0.name", "aut-num"),
 					resource.TestCheckResourceAttr("data.ripedb_object.test", "attributes.0.value", "AS3333"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "rpsl", "aut-num:AS3333\nas-name:RIPE-NCC-AS\nmnt-by:TEST-MNT\nmnt-by:OTHER-MNT\ncreated:2002-09-19T15:12:08Z\nlast-modified:2024-06-10T09:30:01Z\nsource:TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "attributes_map.mnt-by.#", "2"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "attributes_map.mnt-by.1", "OTHER-MNT"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "primary_key", "AS3333"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "created", "2002-09-19T15:12:08Z"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "last_modified", "2024-06-10T09:30:01Z"),
 				),
 			},
 		},
 	})
 }
+
+func TestAccObjectDataSource_Unfiltered(t *testing.T) {
+	srv := testAccServer(t)
+	if err := srv.AddObject(DefaultTestSource, "person: John Smith\nnic-hdl: JS1-TEST\ne-mail: john@example.net\nmnt-by: TEST-MNT\nsource: TEST\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_object" "filtered" {
+					class = "person"
+					value = "JS1-TEST"
+				}
+
+				data "ripedb_object" "unfiltered" {
+					class      = "person"
+					value      = "JS1-TEST"
+					unfiltered = true
+				}
+
+				data "ripedb_object" "unmasked" {
+					class          = "person"
+					value          = "JS1-TEST"
+					unfiltered     = true
+					mask_sensitive = false
+				}
+
+				data "ripedb_object" "mntner" {
+					class      = "mntner"
+					value      = "TEST-MNT"
+					unfiltered = true
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.ripedb_object.filtered", "attributes_map.e-mail.#"),
+					resource.TestCheckResourceAttr("data.ripedb_object.filtered", "sensitive_attributes.#", "0"),
+					resource.TestCheckResourceAttr("data.ripedb_object.unfiltered", "attributes_map.e-mail.0", redacted),
+					resource.TestCheckResourceAttr("data.ripedb_object.unfiltered", "sensitive_attributes.0.name", "e-mail"),
+					resource.TestCheckResourceAttr("data.ripedb_object.unfiltered", "sensitive_attributes.0.value", "john@example.net"),
+					resource.TestCheckResourceAttr("data.ripedb_object.unmasked", "attributes_map.e-mail.0", "john@example.net"),
+					resource.TestCheckResourceAttr("data.ripedb_object.unmasked", "sensitive_attributes.#", "0"),
+					resource.TestCheckResourceAttr("data.ripedb_object.mntner", "attributes_map.auth.0", redacted),
+					resource.TestCheckResourceAttr("data.ripedb_object.mntner", "sensitive_attributes.0.name", "auth"),
+					resource.TestCheckResourceAttr("data.ripedb_object.mntner", "sensitive_attributes.0.value", "MD5-PW $1$abcdefgh$0123456789abcdefghijkl"),
+				),
+			},
+		},
+	})
+}
+
+func TestObjectDataSource_Unfiltered(t *testing.T) {
+	srv := testAccServer(t)
+	client := testRestClient(t, srv)
+	for _, tc := range []struct {
+		unfiltered tftypes.Value
+		mask       tftypes.Value
+		auth       string
+		sensitive  int
+	}{
+		{tftypes.NewValue(tftypes.Bool, nil), tftypes.NewValue(tftypes.Bool, nil), "MD5-PW", 0},
+		{tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.Bool, nil), redacted, 1},
+		{tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.Bool, false), "MD5-PW $1$abcdefgh$0123456789abcdefghijkl", 0},
+	} {
+		state := testReadDataSource(t, NewObjectDataSource(), client, map[string]tftypes.Value{
+			"class":          tftypes.NewValue(tftypes.String, "mntner"),
+			"value":          tftypes.NewValue(tftypes.String, testAccMntner),
+			"unfiltered":     tc.unfiltered,
+			"mask_sensitive": tc.mask,
+		})
+
+		var data ObjectDataSourceModel
+		if diags := state.Get(context.Background(), &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		if auth := data.Rpsl.ValueString(); !strings.Contains(auth, "\nauth:"+tc.auth+"\n") || len(data.SensitiveAttributes) != tc.sensitive {
+			t.Errorf("unfiltered %v, mask_sensitive %v: expected the auth %q and %d sensitive attributes, got %s and %v", tc.unfiltered, tc.mask, tc.auth, tc.sensitive, auth, data.SensitiveAttributes)
+		}
+	}
+}
+
+func TestObjectDataSource_AllowMissing(t *testing.T) {
+	srv := testAccServer(t)
+	state := testReadDataSource(t, NewObjectDataSource(), testRestClient(t, srv), map[string]tftypes.Value{
+		"class":         tftypes.NewValue(tftypes.String, "person"),
+		"value":         tftypes.NewValue(tftypes.String, "JS1-TEST"),
+		"allow_missing": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	var data ObjectDataSourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Found.ValueBool() || data.Id.ValueString() != "person:JS1-TEST" {
+		t.Errorf("expected the person not to be found, got %v", data)
+	}
+
+	if !data.AttributesMap.IsNull() || !data.Rpsl.IsNull() || data.Attributes != nil {
+		t.Errorf("expected the attributes of a missing object to be null, got %v", data)
+	}
+}
+
+func TestAccObjectDataSource_GrsSource(t *testing.T) {
+	srv := testAccServer(t)
+	if err := srv.AddObject("ARIN-GRS", "aut-num: AS701\nas-name: UUNET\nsource: ARIN-GRS\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_object" "test" {
+					class  = "aut-num"
+					value  = "AS701"
+					source = "ARIN-GRS"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "id", "aut-num:AS701"),
+					resource.TestCheckResourceAttr("data.ripedb_object.test", "source", "ARIN-GRS"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectDataSource_AllowMissing(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_object" "missing" {
+					class         = "person"
+					value         = "JS1-TEST"
+					allow_missing = true
+				}
+
+				data "ripedb_object" "found" {
+					class         = "mntner"
+					value         = "TEST-MNT"
+					allow_missing = true
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_object.missing", "id", "person:JS1-TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_object.missing", "found", "false"),
+					resource.TestCheckNoResourceAttr("data.ripedb_object.missing", "attributes.#"),
+					resource.TestCheckNoResourceAttr("data.ripedb_object.missing", "rpsl"),
+					resource.TestCheckResourceAttr("data.ripedb_object.found", "found", "true"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_object" "missing" {
+					class = "person"
+					value = "JS1-TEST"
+				}
+				`,
+				ExpectError: regexp.MustCompile("failed to query RIPE database"),
+			},
+		},
+	})
+}
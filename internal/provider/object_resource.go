@@ -6,11 +6,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/frederic-arr/ripedb-go/ripedb"
 	"github.com/frederic-arr/ripedb-go/ripedb/models"
 	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,6 +21,7 @@ import (
 )
 
 var _ resource.Resource = &ObjectResource{}
+var _ resource.ResourceWithModifyPlan = &ObjectResource{}
 
 func NewObjectResource() resource.Resource {
 	return &ObjectResource{}
@@ -28,13 +30,23 @@ func NewObjectResource() resource.Resource {
 type ObjectResourceModel struct {
 	ObjectModel
 
+	AttributesAll types.List `tfsdk:"attributes_all"`
+
 	SkipValidation    types.Bool `tfsdk:"skip_validation"`
 	IgnoreUnknownKeys types.Bool `tfsdk:"ignore_unknown_keys"`
 	SkipKeys          types.List `tfsdk:"skip_keys"`
+	IgnoreAttributes  types.List `tfsdk:"ignore_attributes"`
+	OmitAttributes    types.List `tfsdk:"omit_attributes"`
+	AppendSource      types.Bool `tfsdk:"append_source"`
 }
 
 type ObjectResource struct {
-	client *ripedb.RipeClient
+	client            RipeDbClient
+	grsSources        []string
+	defaultAttributes []DefaultAttribute
+	ignoreAttributes  []string
+	omitAttributes    []string
+	appendSource      bool
 }
 
 func (r *ObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,6 +75,15 @@ func (r *ObjectResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the object. Defaults to the `source` attribute of the object when `append_source` is `false`, and to the `database` of the provider otherwise",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"attributes": schema.ListNestedAttribute{
 				MarkdownDescription: "the attributes of the object. The first attribute will be used as the object class and key",
 				Required:            true,
@@ -79,6 +100,22 @@ func (r *ObjectResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
+			"attributes_all": schema.ListNestedAttribute{
+				MarkdownDescription: "the attributes of the object, including the `default_attributes` of the provider",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "the name of the attribute",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "the value of the attribute",
+							Computed:            true,
+						},
+					},
+				},
+			},
 			"skip_validation": schema.BoolAttribute{
 				MarkdownDescription: "Skip all local validation. Is OR'ed with the provider-level setting.",
 				Optional:            true,
@@ -92,6 +129,20 @@ func (r *ObjectResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "List of keys to opt-out of validation.",
 				Optional:            true,
 			},
+			"ignore_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the attributes managed outside of Terraform. Their values are kept as found in the database on update, in place, and are not reported as drift. The values of these attributes given in `attributes` are managed alongside them. Is merged with the provider-level setting.",
+				Optional:            true,
+			},
+			"omit_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the server-managed attributes stripped from the object read from the database. Overrides the provider-level setting.",
+				Optional:            true,
+			},
+			"append_source": schema.BoolAttribute{
+				MarkdownDescription: "Whether the `source` attribute is appended to the object. When `false`, the `source` attribute must be given in `attributes`, and the object is sent to that source. Overrides the provider-level setting.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -113,9 +164,175 @@ func (r *ObjectResource) Configure(ctx context.Context, req resource.ConfigureRe
 	}
 
 	r.client = data.Client
+	r.grsSources = data.GrsSources
+	r.defaultAttributes = data.DefaultAttributes
+	r.ignoreAttributes = data.IgnoreAttributes
+	r.omitAttributes = data.OmitAttributes
+	r.appendSource = data.AppendSource
+}
+
+// ignoredAttributes returns the names of the attributes managed outside of
+// Terraform, from both the provider and the object settings.
+func (r *ObjectResource) ignoredAttributes(ctx context.Context, data *ObjectResourceModel) ([]string, diag.Diagnostics) {
+	ignored := slices.Clone(r.ignoreAttributes)
+	if data.IgnoreAttributes.IsNull() || data.IgnoreAttributes.IsUnknown() {
+		return ignored, nil
+	}
+
+	var names []string
+	diags := data.IgnoreAttributes.ElementsAs(ctx, &names, false)
+	return append(ignored, names...), diags
+}
+
+// omittedAttributes returns the names of the server-managed attributes which
+// are stripped from the object. The `source` attribute is kept when it is
+// taken from the configuration.
+func (r *ObjectResource) omittedAttributes(ctx context.Context, data *ObjectResourceModel) ([]string, diag.Diagnostics) {
+	omitted := slices.Clone(r.omitAttributes)
+	var diags diag.Diagnostics
+	if !data.OmitAttributes.IsNull() && !data.OmitAttributes.IsUnknown() {
+		omitted = nil
+		diags = data.OmitAttributes.ElementsAs(ctx, &omitted, false)
+	}
+
+	if !r.shouldAppendSource(data) {
+		omitted = slices.DeleteFunc(omitted, func(name string) bool { return name == "source" })
+	}
+
+	return omitted, diags
+}
+
+// shouldAppendSource reports whether the `source` attribute is appended to the
+// object instead of being taken from its attributes.
+func (r *ObjectResource) shouldAppendSource(data *ObjectResourceModel) bool {
+	if data.AppendSource.IsNull() || data.AppendSource.IsUnknown() {
+		return r.appendSource
+	}
+
+	return data.AppendSource.ValueBool()
+}
+
+// configuredSource returns the value of the source attribute of the object
+// when the source is not appended, or an empty string.
+func (r *ObjectResource) configuredSource(data *ObjectResourceModel) string {
+	if r.shouldAppendSource(data) {
+		return ""
+	}
+
+	for _, a := range data.Attributes {
+		if a.Name.ValueString() == "source" {
+			return a.Value.ValueString()
+		}
+	}
+
+	return ""
+}
+
+// defaults returns the default attributes of the provider which are not
+// ignored.
+func (r *ObjectResource) defaults(ignored []string) []DefaultAttribute {
+	return slices.DeleteFunc(slices.Clone(r.defaultAttributes), func(a DefaultAttribute) bool {
+		return slices.Contains(ignored, a.Name)
+	})
+}
+
+func (r *ObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to merge when the object is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ObjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ignored, diags := r.ignoredAttributes(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	known := !data.Class.IsUnknown() && !data.IgnoreAttributes.IsUnknown() && !slices.ContainsFunc(data.Attributes, func(a ObjectModelAttribute) bool {
+		return a.Name.IsUnknown() || a.Value.IsUnknown()
+	})
+
+	if known && !data.AppendSource.IsUnknown() && !r.shouldAppendSource(&data) && !hasAttributeNamed(data.Attributes, "source") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attributes"),
+			"Missing source attribute",
+			"The source attribute is not appended to the object, it must be given in its attributes.",
+		)
+	}
+
+	// Without the appended source, the object is sent to the source it declares.
+	if source := r.configuredSource(&data); known && r.client != nil && !data.AppendSource.IsUnknown() && source != "" {
+		var configSource types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source"), &configSource)...)
+		switch {
+		case configSource.IsUnknown():
+		case !configSource.IsNull() && !strings.EqualFold(configSource.ValueString(), source):
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source"),
+				"Conflicting source",
+				fmt.Sprintf("The source of the object is %q, but its source attribute is %q.", configSource.ValueString(), source),
+			)
+		case configSource.IsNull():
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source"), types.StringValue(source))...)
+
+			var stateSource types.String
+			if !req.State.Raw.IsNull() {
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source"), &stateSource)...)
+			}
+
+			if !stateSource.IsNull() && stateSource.ValueString() != source {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("source"))
+			}
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The default attributes are not known until the provider is configured.
+	attributesAll := types.ListUnknown(objectAttributeType)
+	if known && r.client != nil {
+		attributesAll, diags = types.ListValueFrom(ctx, objectAttributeType, mergeDefaultAttributes(data.Class.ValueString(), data.Attributes, r.defaults(ignored)))
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("attributes_all"), attributesAll)...)
+}
+
+// splitDefaultAttributes sets `attributes_all` to the attributes returned by
+// the database and removes the default attributes from `attributes`, given the
+// attributes explicitly configured on the object.
+func (r *ObjectResource) splitDefaultAttributes(ctx context.Context, configured []ObjectModelAttribute, ignored []string, data *ObjectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.AttributesAll, diags = types.ListValueFrom(ctx, objectAttributeType, data.Attributes)
+	data.Attributes = removeDefaultAttributes(data.Class.ValueString(), data.Attributes, configured, r.defaults(ignored))
+	return diags
+}
+
+// source returns the source of the object: the value of its source attribute
+// when the source is not appended, else its source field, falling back to the
+// source of the provider.
+func (r *ObjectResource) source(data *ObjectResourceModel) string {
+	if source := r.configuredSource(data); source != "" {
+		return source
+	}
+
+	if data.Source.IsNull() || data.Source.IsUnknown() || data.Source.ValueString() == "" {
+		return r.client.GetSource()
+	}
+
+	return data.Source.ValueString()
 }
 
 func (r *ObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if unconfiguredClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var data ObjectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -123,13 +340,31 @@ func (r *ObjectResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	resource := data.Class.ValueString()
+	source := r.source(&data)
+	if isGrsSource(source, r.grsSources) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Read-only source",
+			fmt.Sprintf("The %s source is a mirror of another registry, its objects cannot be managed.", source),
+		)
+		return
+	}
+
+	ignored, diags := r.ignoredAttributes(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	omitted, diags := r.omittedAttributes(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	configured := data.Attributes
+	data.Attributes = mergeDefaultAttributes(resource, configured, r.defaults(ignored))
 	obj := modelToObject(&data.ObjectModel)
 
 	// The first attribute should always be the class of the object
 	// Since the class is given in a separate field, we have to prepend it
-	// We also always add the source as it is already specified in the provider
+	// Unless it is given in the attributes, we also add the source, either given on the object or specified in the provider
 	obj.Attributes = append([]rpsl.Attribute{{Name: resource, Value: data.Value.ValueString()}}, obj.Attributes...)
-	obj.Attributes = append(obj.Attributes, rpsl.Attribute{Name: "source", Value: (*r.client).GetSource()})
+	if r.shouldAppendSource(&data) {
+		obj.Attributes = append(obj.Attributes, rpsl.Attribute{Name: "source", Value: source})
+	}
 
 	skipValidation := r.client.GetSkipValidation() || data.SkipValidation.ValueBool()
 	skipUnknownKeys := r.client.GetSkipUnknownKeys() || data.IgnoreUnknownKeys.ValueBool()
@@ -152,7 +387,7 @@ func (r *ObjectResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
-	obj, err = (*r.client).CreateObjectWithOptions(resource, obj, skipValidation, skipUnknownKeys, skipKeys)
+	obj, err = r.client.CreateObjectWithOptions(ctx, source, resource, obj, skipValidation, skipUnknownKeys, skipKeys)
 	if err != nil {
 		resp.Diagnostics.AddError("failed to create object in RIPE database", err.Error())
 		return
@@ -160,12 +395,18 @@ func (r *ObjectResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Remove the first field and timestamps
 	// first field: we already specify its data in the .class and .value fields
-	filterObject(obj, &data.ObjectModel)
+	filterObject(withoutPreservedAttributes(obj, data.Attributes, ignored), &data.ObjectModel, omitted)
+	resp.Diagnostics.Append(r.splitDefaultAttributes(ctx, configured, ignored, &data)...)
 	data.Id = types.StringValue(fmt.Sprintf("%s:%s", resource, m.Key()))
+	data.Source = types.StringValue(source)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unconfiguredClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var data ObjectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -174,34 +415,68 @@ func (r *ObjectResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	id := data.Id.ValueString()
 	idParts := strings.SplitN(id, ":", 2)
-	obj, err := (*r.client).GetObject(idParts[0], idParts[1])
+	source := r.source(&data)
+	obj, err := r.client.GetObject(ctx, source, idParts[0], idParts[1])
 	if err != nil {
 		resp.Diagnostics.AddError("failed to query RIPE database", err.Error())
 		return
 	}
 
-	filterObject(obj, &data.ObjectModel)
+	ignored, diags := r.ignoredAttributes(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	omitted, diags := r.omittedAttributes(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	configured := data.Attributes
+	filterObject(withoutPreservedAttributes(obj, configured, ignored), &data.ObjectModel, omitted)
 	data.Class = types.StringValue(idParts[0])
+	resp.Diagnostics.Append(r.splitDefaultAttributes(ctx, configured, ignored, &data)...)
+	data.Source = types.StringValue(source)
 	data.Value = types.StringValue(obj.Attributes[0].Value)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ObjectResourceModel
+	if unconfiguredClient(r.client, &resp.Diagnostics) {
+		return
+	}
 
+	var data ObjectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resource := data.Class.ValueString()
+	source := r.source(&data)
+	ignored, diags := r.ignoredAttributes(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	omitted, diags := r.omittedAttributes(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	configured := data.Attributes
+	data.Attributes = mergeDefaultAttributes(resource, configured, r.defaults(ignored))
 	obj := modelToObject(&data.ObjectModel)
 
 	// The first attribute should always be the class of the object
 	// Since the class is given in a separate field, we have to prepend it
-	// We also always add the source as it is already specified in the provider
 	obj.Attributes = append([]rpsl.Attribute{{Name: resource, Value: data.Value.ValueString()}}, obj.Attributes...)
-	obj.Attributes = append(obj.Attributes, rpsl.Attribute{Name: "source", Value: (*r.client).GetSource()})
+
+	// Unless it is given in the attributes, we also add the source, either given on the object or specified in the provider
+	if r.shouldAppendSource(&data) {
+		obj.Attributes = append(obj.Attributes, rpsl.Attribute{Name: "source", Value: source})
+	}
+
+	// The ignored attributes are managed outside of Terraform, keep them as they currently are in the database
+	if len(ignored) > 0 {
+		idParts := strings.SplitN(data.Id.ValueString(), ":", 2)
+		current, err := r.client.GetObject(ctx, source, idParts[0], idParts[1])
+		if err != nil {
+			resp.Diagnostics.AddError("failed to query RIPE database", err.Error())
+			return
+		}
+
+		preserved := slices.DeleteFunc(slices.Clone(ignored), func(name string) bool { return slices.Contains(omitted, name) })
+		obj.Attributes = mergeIgnoredAttributes(obj.Attributes, current.Attributes, preserved)
+	}
 
 	skipValidation := r.client.GetSkipValidation() || data.SkipValidation.ValueBool()
 	skipUnknownKeys := r.client.GetSkipUnknownKeys() || data.IgnoreUnknownKeys.ValueBool()
@@ -224,7 +499,7 @@ func (r *ObjectResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 	}
 
-	obj, err = (*r.client).UpdateObjectWithOptions(resource, m.Key(), obj, skipValidation, skipUnknownKeys, skipKeys)
+	obj, err = r.client.UpdateObjectWithOptions(ctx, source, resource, m.Key(), obj, skipValidation, skipUnknownKeys, skipKeys)
 	if err != nil {
 		resp.Diagnostics.AddError("failed to update RIPE database object", err.Error())
 		return
@@ -232,13 +507,18 @@ func (r *ObjectResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Remove the first field and timestamps
 	// first field: we already specify its data in the .class and .value fields
-	filterObject(obj, &data.ObjectModel)
+	filterObject(withoutPreservedAttributes(obj, data.Attributes, ignored), &data.ObjectModel, omitted)
+	resp.Diagnostics.Append(r.splitDefaultAttributes(ctx, configured, ignored, &data)...)
+	data.Source = types.StringValue(source)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data ObjectResourceModel
+	if unconfiguredClient(r.client, &resp.Diagnostics) {
+		return
+	}
 
+	var data ObjectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -246,7 +526,7 @@ func (r *ObjectResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	id := data.Id.ValueString()
 	idParts := strings.SplitN(id, ":", 2)
-	_, err := (*r.client).DeleteObject(idParts[0], idParts[1])
+	_, err := r.client.DeleteObject(ctx, r.source(&data), idParts[0], idParts[1])
 	if err != nil {
 		resp.Diagnostics.AddError("failed to delete RIPE database object", err.Error())
 		return
@@ -0,0 +1,158 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-ripedb/internal/ripetest"
+)
+
+func testAccObjectResourceConfig(srv *ripetest.Server, phone string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "ripedb_object" "test" {
+  class = "person"
+  value = "John Smith"
+  attributes = [
+    { name = "nic-hdl", value = "JS1-TEST" },
+    { name = "address", value = "ACME, Inc." },
+    { name = "phone", value = %q },
+    { name = "e-mail", value = "john@example.net" },
+    { name = "mnt-by", value = "TEST-MNT" },
+  ]
+}
+`, phone)
+}
+
+// testAccCheckObjectAttribute checks the value of an attribute of an object
+// stored in the in-memory database.
+func testAccCheckObjectAttribute(srv *ripetest.Server, class string, key string, name string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj := srv.Object(DefaultTestSource, class, key)
+		if obj == nil {
+			return fmt.Errorf("%s %s does not exist", class, key)
+		}
+
+		if v := obj.GetFirst(name); v == nil || *v != value {
+			return fmt.Errorf("expected %s of %s %s to be %q, got %v", name, class, key, value, v)
+		}
+
+		return nil
+	}
+}
+
+func TestAccObjectResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if srv.Object(DefaultTestSource, "person", "JS1-TEST") != nil {
+				return fmt.Errorf("person JS1-TEST still exists")
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccObjectResourceConfig(srv, "+0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ripedb_object.test", "id", "person:JS1-TEST"),
+					resource.TestCheckResourceAttr("ripedb_object.test", "source", DefaultTestSource),
+					resource.TestCheckResourceAttr("ripedb_object.test", "attributes.#", "5"),
+					resource.TestCheckResourceAttr("ripedb_object.test", "attributes_all.#", "5"),
+					testAccCheckObjectAttribute(srv, "person", "JS1-TEST", "phone", "+0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ripedb_object.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccObjectResourceConfig(srv, "+1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ripedb_object.test", "attributes.2.value", "+1"),
+					testAccCheckObjectAttribute(srv, "person", "JS1-TEST", "phone", "+1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccObjectResource_Unauthorized(t *testing.T) {
+	srv := testAccServer(t)
+	srv.SetPassword(testAccMntner, "another password")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccObjectResourceConfig(srv, "+0"),
+				ExpectError: regexp.MustCompile(`not authenticated by: TEST-MNT`),
+			},
+		},
+	})
+}
+
+func TestAccObjectResource_SourceAttribute(t *testing.T) {
+	srv := testAccServer(t)
+	if err := srv.AddObject("OTHER", "mntner: TEST-MNT\nauth: MD5-PW $1$abcdefgh$0123456789abcdefghijkl\nmnt-by: TEST-MNT\nsource: OTHER\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(source string) string {
+		return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "ripedb_object" "test" {
+  class         = "person"
+  value         = "John Smith"
+  append_source = false
+  %s
+  attributes = [
+    { name = "nic-hdl", value = "JS1-TEST" },
+    { name = "address", value = "ACME, Inc." },
+    { name = "phone", value = "+0" },
+    { name = "e-mail", value = "john@example.net" },
+    { name = "mnt-by", value = "TEST-MNT" },
+    { name = "source", value = "OTHER" },
+  ]
+}
+`, source)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`source = "TEST"`),
+				ExpectError: regexp.MustCompile(`Conflicting source`),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ripedb_object.test", "source", "OTHER"),
+					func(s *terraform.State) error {
+						if srv.Object("OTHER", "person", "JS1-TEST") == nil {
+							return fmt.Errorf("expected the person to be created in the OTHER source")
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
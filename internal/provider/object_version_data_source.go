@@ -0,0 +1,143 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ObjectVersionDataSource{}
+
+func NewObjectVersionDataSource() datasource.DataSource {
+	return &ObjectVersionDataSource{}
+}
+
+type ObjectVersionDataSource struct {
+	client RipeDbClient
+}
+
+type ObjectVersionDataSourceModel struct {
+	ObjectModel
+	Revision      types.Int64 `tfsdk:"revision"`
+	Unfiltered    types.Bool  `tfsdk:"unfiltered"`
+	MaskSensitive types.Bool  `tfsdk:"mask_sensitive"`
+
+	SensitiveAttributes []ObjectModelAttribute `tfsdk:"sensitive_attributes"`
+}
+
+func (d *ObjectVersionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_version"
+}
+
+func (d *ObjectVersionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source provides an object of the RIPE Database as it was at a given revision, as listed by the `ripedb_object_versions` data source.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the ID of the object",
+				Computed:            true,
+			},
+			"class": schema.StringAttribute{
+				MarkdownDescription: "the class of the object",
+				Required:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "the key of the object",
+				Required:            true,
+			},
+			"revision": schema.Int64Attribute{
+				MarkdownDescription: "the revision number of the version",
+				Required:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the object. Defaults to the `database` of the provider",
+				Optional:            true,
+				Computed:            true,
+			},
+			"attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "the attributes of the object at the revision",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "the name of the attribute",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "the value of the attribute",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"unfiltered":           unfilteredAttribute(),
+			"mask_sensitive":       maskSensitiveAttribute(),
+			"sensitive_attributes": sensitiveAttributesAttribute(),
+		},
+	}
+}
+
+func (d *ObjectVersionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *ObjectVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	client, ok := clientCapability[VersionsClient](d.client, "object versions", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var data ObjectVersionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := d.client.GetSource()
+	if !data.Source.IsNull() {
+		source = data.Source.ValueString()
+	}
+
+	resource := data.Class.ValueString()
+	obj, err := client.GetVersion(ctx, source, resource, data.Value.ValueString(), data.Revision.ValueInt64(), data.Unfiltered.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("failed to query RIPE database", err.Error())
+		return
+	}
+
+	data.SensitiveAttributes = []ObjectModelAttribute{}
+	if masksSensitive(data.Unfiltered, data.MaskSensitive) {
+		obj, data.SensitiveAttributes = maskSensitive(obj)
+	}
+
+	objectToModel(obj, &data.ObjectModel)
+	data.Id = types.StringValue(fmt.Sprintf("%s:%s", resource, objectKey(resource, obj)))
+	data.Source = types.StringValue(source)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
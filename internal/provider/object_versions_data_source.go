@@ -0,0 +1,148 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ObjectVersionsDataSource{}
+
+func NewObjectVersionsDataSource() datasource.DataSource {
+	return &ObjectVersionsDataSource{}
+}
+
+type ObjectVersionsDataSource struct {
+	client RipeDbClient
+}
+
+type ObjectVersionsDataSourceModel struct {
+	Id       types.String              `tfsdk:"id"`
+	Class    types.String              `tfsdk:"class"`
+	Value    types.String              `tfsdk:"value"`
+	Source   types.String              `tfsdk:"source"`
+	Versions []ObjectVersionEntryModel `tfsdk:"versions"`
+}
+
+type ObjectVersionEntryModel struct {
+	Revision  types.Int64  `tfsdk:"revision"`
+	Date      types.String `tfsdk:"date"`
+	Operation types.String `tfsdk:"operation"`
+}
+
+func (d *ObjectVersionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_versions"
+}
+
+func (d *ObjectVersionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source provides the history of an object in the RIPE Database. The attributes of a revision are provided by the `ripedb_object_version` data source.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the ID of the object",
+				Computed:            true,
+			},
+			"class": schema.StringAttribute{
+				MarkdownDescription: "the class of the object",
+				Required:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "the key of the object",
+				Required:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the object. Defaults to the `database` of the provider",
+				Optional:            true,
+				Computed:            true,
+			},
+			"versions": schema.ListNestedAttribute{
+				MarkdownDescription: "the versions of the object, oldest first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"revision": schema.Int64Attribute{
+							MarkdownDescription: "the revision number of the version",
+							Computed:            true,
+						},
+						"date": schema.StringAttribute{
+							MarkdownDescription: "the date of the change",
+							Computed:            true,
+						},
+						"operation": schema.StringAttribute{
+							MarkdownDescription: "the operation of the change, e.g. `ADD/UPD` or `DEL`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ObjectVersionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *ObjectVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	client, ok := clientCapability[VersionsClient](d.client, "object versions", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var data ObjectVersionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := d.client.GetSource()
+	if !data.Source.IsNull() {
+		source = data.Source.ValueString()
+	}
+
+	resource := data.Class.ValueString()
+	versions, err := client.GetVersions(ctx, source, resource, data.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("failed to query RIPE database", err.Error())
+		return
+	}
+
+	data.Versions = []ObjectVersionEntryModel{}
+	for _, v := range versions {
+		data.Versions = append(data.Versions, ObjectVersionEntryModel{
+			Revision:  types.Int64Value(v.Revision),
+			Date:      types.StringValue(v.Date),
+			Operation: types.StringValue(v.Operation),
+		})
+	}
+
+	data.Id = types.StringValue(fmt.Sprintf("%s:%s", resource, data.Value.ValueString()))
+	data.Source = types.StringValue(source)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccObjectVersionsDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	if err := srv.AddObject(DefaultTestSource, "person: John Smith\nnic-hdl: JS1-TEST\naddress: ACME, Inc.\nphone: +0\ne-mail: john@example.net\nmnt-by: TEST-MNT\nsource: TEST\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	source := DefaultTestSource
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source, ApiKey: &testAccApiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	person := srv.Object(DefaultTestSource, "person", "JS1-TEST")
+	person.Attributes[3].Value = "+1"
+	if _, err := client.UpdateObjectWithOptions(context.Background(), "", "person", "JS1-TEST", person, false, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_object_versions" "test" {
+					class = "person"
+					value = "JS1-TEST"
+				}
+
+				data "ripedb_object_version" "test" {
+					class    = "person"
+					value    = "JS1-TEST"
+					revision = data.ripedb_object_versions.test.versions[0].revision
+				}
+
+				data "ripedb_object_version" "unfiltered" {
+					class      = "person"
+					value      = "JS1-TEST"
+					revision   = data.ripedb_object_versions.test.versions[0].revision
+					unfiltered = true
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_object_versions.test", "source", "TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_object_versions.test", "versions.#", "2"),
+					resource.TestCheckResourceAttr("data.ripedb_object_versions.test", "versions.1.revision", "2"),
+					resource.TestCheckResourceAttr("data.ripedb_object_version.test", "id", "person:JS1-TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_object_version.test", "attributes.3.value", "+0"),
+					resource.TestCheckResourceAttr("data.ripedb_object_version.test", "sensitive_attributes.#", "0"),
+					resource.TestCheckResourceAttr("data.ripedb_object_version.unfiltered", "attributes.4.value", redacted),
+					resource.TestCheckResourceAttr("data.ripedb_object_version.unfiltered", "sensitive_attributes.0.name", "e-mail"),
+					resource.TestCheckResourceAttr("data.ripedb_object_version.unfiltered", "sensitive_attributes.0.value", "john@example.net"),
+				),
+			},
+		},
+	})
+}
+
+func TestObjectVersionDataSource_Unfiltered(t *testing.T) {
+	srv := testAccServer(t)
+	if err := srv.AddObject(DefaultTestSource, "person: John Smith\nnic-hdl: JS1-TEST\naddress: ACME, Inc.\nphone: +0\ne-mail: john@example.net\nmnt-by: TEST-MNT\nsource: TEST\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	client := testRestClient(t, srv)
+	for _, tc := range []struct {
+		unfiltered tftypes.Value
+		mask       tftypes.Value
+		email      string
+		sensitive  int
+	}{
+		{tftypes.NewValue(tftypes.Bool, nil), tftypes.NewValue(tftypes.Bool, nil), "", 0},
+		{tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.Bool, nil), redacted, 1},
+		{tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.Bool, false), "john@example.net", 0},
+	} {
+		state := testReadDataSource(t, NewObjectVersionDataSource(), client, map[string]tftypes.Value{
+			"class":          tftypes.NewValue(tftypes.String, "person"),
+			"value":          tftypes.NewValue(tftypes.String, "JS1-TEST"),
+			"revision":       tftypes.NewValue(tftypes.Number, 1),
+			"unfiltered":     tc.unfiltered,
+			"mask_sensitive": tc.mask,
+		})
+
+		var data ObjectVersionDataSourceModel
+		if diags := state.Get(context.Background(), &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		email := ""
+		for _, attr := range data.Attributes {
+			if attr.Name.ValueString() == "e-mail" {
+				email = attr.Value.ValueString()
+			}
+		}
+
+		if email != tc.email || len(data.SensitiveAttributes) != tc.sensitive {
+			t.Errorf("unfiltered %v, mask_sensitive %v: expected the e-mail %q and %d sensitive attributes, got %q and %v", tc.unfiltered, tc.mask, tc.email, tc.sensitive, email, data.SensitiveAttributes)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ObjectsDataSource{}
+
+// DefaultObjectsConcurrency is the default number of objects looked up at the
+// same time by the `ripedb_objects` data source.
+const DefaultObjectsConcurrency = 8
+
+func NewObjectsDataSource() datasource.DataSource {
+	return &ObjectsDataSource{}
+}
+
+type ObjectsDataSource struct {
+	client     RipeDbClient
+	grsSources []string
+}
+
+type ObjectsDataSourceModel struct {
+	Id            types.String                     `tfsdk:"id"`
+	Ids           types.List                       `tfsdk:"ids"`
+	Source        types.String                     `tfsdk:"source"`
+	Concurrency   types.Int64                      `tfsdk:"concurrency"`
+	AllowMissing  types.Bool                       `tfsdk:"allow_missing"`
+	Unfiltered    types.Bool                       `tfsdk:"unfiltered"`
+	MaskSensitive types.Bool                       `tfsdk:"mask_sensitive"`
+	Objects       map[string]UnfilteredObjectModel `tfsdk:"objects"`
+	Missing       types.List                       `tfsdk:"missing"`
+}
+
+func (d *ObjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_objects"
+}
+
+func (d *ObjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	objectsSchema := schema.MapNestedAttribute{
+		MarkdownDescription: "the objects, keyed by their ID as given in `ids`",
+		Computed:            true,
+		NestedObject:        objectNestedAttributeObject(),
+	}
+	objectsSchema.NestedObject.Attributes["sensitive_attributes"] = sensitiveAttributesAttribute()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source looks up several objects of the RIPE Database at once, concurrently, e.g. the persons of a team.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the IDs of the objects, comma-separated",
+				Computed:            true,
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the IDs of the objects to look up, as `class:key`, e.g. `person:JS1-RIPE`",
+				Required:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the objects. When unset, each object is looked up in the `database` of the provider, then in its `grs_sources`",
+				Optional:            true,
+			},
+			"concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("the maximum number of objects looked up at the same time. The `max_concurrent_requests` of the provider still applies. Defaults to `%d`", DefaultObjectsConcurrency),
+				Optional:            true,
+			},
+			"allow_missing": schema.BoolAttribute{
+				MarkdownDescription: "whether to leave the objects which do not exist out of `objects` rather than failing. Defaults to `false`",
+				Optional:            true,
+			},
+			"unfiltered":     unfilteredAttribute(),
+			"mask_sensitive": maskSensitiveAttribute(),
+			"objects":        objectsSchema,
+			"missing": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the IDs of the objects which do not exist, when `allow_missing` is set",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ObjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+	d.grsSources = data.GrsSources
+}
+
+func (d *ObjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data ObjectsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := []string{}
+	resp.Diagnostics.Append(listToStrings(ctx, data.Ids, &ids)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, id := range ids {
+		if class, key, ok := strings.Cut(id, ":"); !ok || class == "" || key == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ids").AtListIndex(i),
+				"Invalid object ID",
+				fmt.Sprintf("The object IDs must be written `class:key`, got %q.", id),
+			)
+		}
+	}
+
+	concurrency := DefaultObjectsConcurrency
+	if !data.Concurrency.IsNull() {
+		concurrency = int(data.Concurrency.ValueInt64())
+	}
+
+	if concurrency < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("concurrency"), "Invalid concurrency", "The concurrency must be at least 1.")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sources := append([]string{d.client.GetSource()}, d.grsSources...)
+	if !data.Source.IsNull() {
+		sources = []string{data.Source.ValueString()}
+	}
+
+	type result struct {
+		obj    *rpsl.Object
+		source string
+		err    error
+	}
+
+	// The results are written at the index of their ID, so that the
+	// diagnostics and the missing objects keep the order of the IDs.
+	results := make([]result, len(ids))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			class, key, _ := strings.Cut(id, ":")
+			obj, source, err := lookupObject(ctx, d.client, sources, class, key, data.Unfiltered.ValueBool())
+			results[i] = result{obj, source, err}
+		}()
+	}
+
+	wg.Wait()
+
+	data.Objects = map[string]UnfilteredObjectModel{}
+	missing := []string{}
+	for i, id := range ids {
+		r := results[i]
+		if isNotFound(r.err) && data.AllowMissing.ValueBool() {
+			missing = append(missing, id)
+			continue
+		}
+
+		if r.err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to query RIPE database for %s", id), r.err.Error())
+			continue
+		}
+
+		obj := r.obj
+		sensitive := []ObjectModelAttribute{}
+		if masksSensitive(data.Unfiltered, data.MaskSensitive) {
+			obj, sensitive = maskSensitive(obj)
+		}
+
+		class, _, _ := strings.Cut(id, ":")
+		key := objectKey(class, obj)
+		m := UnfilteredObjectModel{
+			ObjectModel: ObjectModel{
+				Id:     types.StringValue(fmt.Sprintf("%s:%s", class, key)),
+				Class:  types.StringValue(class),
+				Value:  types.StringValue(key),
+				Source: types.StringValue(r.source),
+			},
+			SensitiveAttributes: sensitive,
+		}
+
+		objectToModel(obj, &m.ObjectModel)
+		data.Objects[id] = m
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Missing, diags = types.ListValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(diags...)
+
+	data.Id = types.StringValue(strings.Join(ids, ","))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccObjectsDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	for _, obj := range []string{
+		"person: John Smith\nnic-hdl: JS1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"person: Jane Doe\nnic-hdl: JD1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_objects" "test" {
+					ids           = ["person:JS1-TEST", "person:JD1-TEST", "person:NONE-TEST", "mntner:TEST-MNT"]
+					concurrency   = 2
+					allow_missing = true
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_objects.test", "objects.%", "3"),
+					resource.TestCheckResourceAttr("data.ripedb_objects.test", "objects.person:JD1-TEST.value", "JD1-TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_objects.test", "objects.mntner:TEST-MNT.source", "TEST"),
+					resource.TestCheckResourceAttr("data.ripedb_objects.test", "missing.#", "1"),
+					resource.TestCheckResourceAttr("data.ripedb_objects.test", "missing.0", "person:NONE-TEST"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_objects" "test" {
+					ids = ["person:JS1-TEST", "person:NONE-TEST"]
+				}
+				`,
+				ExpectError: regexp.MustCompile("failed to query RIPE database for person:NONE-TEST"),
+			},
+		},
+	})
+}
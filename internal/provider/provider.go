@@ -5,10 +5,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
-	"github.com/frederic-arr/ripedb-go/ripedb"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,10 +25,22 @@ var _ provider.Provider = &RipeDbProvider{}
 var _ provider.ProviderWithFunctions = &RipeDbProvider{}
 
 const (
-	DefaultEndpoint = "https://rest.db.ripe.net"
-	DefaultSource   = "RIPE"
+	DefaultEndpoint     = "https://rest.db.ripe.net"
+	DefaultMtlsEndpoint = "https://rest-cert.db.ripe.net"
+	DefaultSource       = "RIPE"
+
+	BackendRest  = "rest"
+	BackendDump  = "dump"
+	BackendWhois = "whois"
+
+	DefaultMaxRetries   = 3
+	DefaultRetryMinWait = 1
+	DefaultRetryMaxWait = 30
 )
 
+// Backends lists the values accepted by the `backend` setting.
+var Backends = []string{BackendRest, BackendDump, BackendWhois}
+
 // RipeDbProvider defines the provider implementation.
 type RipeDbProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -34,8 +51,15 @@ type RipeDbProvider struct {
 
 // RipeDbProviderModel describes the provider data model.
 type RipeDbProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Source   types.String `tfsdk:"database"`
+	Backend        types.String `tfsdk:"backend"`
+	DumpPath       types.String `tfsdk:"dump_path"`
+	WhoisAddress   types.String `tfsdk:"whois_address"`
+	Environment    types.String `tfsdk:"environment"`
+	RewriteHandles types.Bool   `tfsdk:"rewrite_handles"`
+	TestHandles    types.List   `tfsdk:"test_handles"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Source         types.String `tfsdk:"database"`
+	GrsSources     types.List   `tfsdk:"grs_sources"`
 
 	ApiKey types.String `tfsdk:"api_key"`
 
@@ -49,10 +73,44 @@ type RipeDbProviderModel struct {
 
 	SkipValidation    types.Bool `tfsdk:"skip_validation"`
 	IgnoreUnknownKeys types.Bool `tfsdk:"ignore_unknown_keys"`
+
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryMinWait types.Int64 `tfsdk:"retry_min_wait"`
+	RetryMaxWait types.Int64 `tfsdk:"retry_max_wait"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	DefaultAttributes []DefaultAttributeModel `tfsdk:"default_attributes"`
+	IgnoreAttributes  types.List              `tfsdk:"ignore_attributes"`
+	OmitAttributes    types.List              `tfsdk:"omit_attributes"`
+	AppendSource      types.Bool              `tfsdk:"append_source"`
+}
+
+// unconfiguredClient adds an error to the diagnostics and returns true when the
+// client has not been configured, which happens when the provider
+// configuration is unknown and Terraform does not support deferred actions.
+func unconfiguredClient(client RipeDbClient, diags *diag.Diagnostics) bool {
+	if client != nil {
+		return false
+	}
+
+	diags.AddError(
+		"Unconfigured RIPE database client",
+		"The provider configuration depends on values which are not known until apply, so the RIPE database cannot be queried yet. "+
+			"Use a Terraform version supporting deferred actions, or apply the resources the provider configuration depends on first with -target.",
+	)
+
+	return true
 }
 
 type RipeDbProviderData struct {
-	Client *ripedb.RipeClient
+	Client            RipeDbClient
+	GrsSources        []string
+	DefaultAttributes []DefaultAttribute
+	IgnoreAttributes  []string
+	OmitAttributes    []string
+	AppendSource      bool
 }
 
 func (p *RipeDbProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -64,6 +122,31 @@ func (p *RipeDbProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "The RIPE DB provider is used to interact with the objects in the RIPE database. The provider needs to be configured with the proper credentials before objects can be modified.",
 		Attributes: map[string]schema.Attribute{
+			"backend": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The backend serving the objects: `%s` for the RIPE Database RESTful API, `%s` for the RIPE split database dumps found in `dump_path`, which need no network access, or `%s` for the whois protocol served at `whois_address`. The `%s` and `%s` backends are read-only. Defaults to `%s`.", BackendRest, BackendDump, BackendWhois, BackendDump, BackendWhois, BackendRest),
+				Optional:            true,
+			},
+			"dump_path": schema.StringAttribute{
+				MarkdownDescription: "The directory holding the RIPE split database dumps (`ripe.db.<class>.gz`), as published on the RIPE NCC FTP server. Required when `backend` is `dump`. The objects are attributed to `database`.",
+				Optional:            true,
+			},
+			"whois_address": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The `host:port` address of the whois server used when `backend` is `%s`. Defaults to `%s`.", BackendWhois, DefaultWhoisAddress),
+				Optional:            true,
+			},
+			"environment": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The RIPE Database environment, either `%s` or `%s`. It sets the defaults of `endpoint` and `database`: `%s` and `%s` in production, `%s` and `%s` in the TEST database. Defaults to `%s`.", EnvironmentProd, EnvironmentTest, DefaultEndpoint, DefaultSource, DefaultTestEndpoint, DefaultTestSource, EnvironmentProd),
+				Optional:            true,
+			},
+			"rewrite_handles": schema.BoolAttribute{
+				MarkdownDescription: fmt.Sprintf("Rewrite the production handles (e.g. `JS1-RIPE`) and the `RIPE` source to their TEST database equivalent (`JS1-TEST` and `TEST`) in every request, and back in every response, so that the same configuration can be rehearsed on the TEST database. Every handle ending with `-TEST` is rewritten back to `-RIPE`, unless it is listed in `test_handles`. Requires `environment` to be `%s`.", EnvironmentTest),
+				Optional:            true,
+			},
+			"test_handles": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The handles of the TEST database ending with `-TEST` which are not rewritten back to `-RIPE` by `rewrite_handles`, e.g. the handles written with `-TEST` in the configuration. Requires `rewrite_handles`.",
+				Optional:            true,
+			},
 			"endpoint": schema.StringAttribute{
 				MarkdownDescription: "The endpoint of the RIPE Database RESTful API.",
 				Optional:            true,
@@ -72,6 +155,11 @@ func (p *RipeDbProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "The database where the queries should be made. This is equivalent to the `source` field of the objects.",
 				Optional:            true,
 			},
+			"grs_sources": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The read-only mirrors of other registries (e.g. `ARIN-GRS`, `RADB-GRS`) which the data sources fall back to, in order, when an object without an explicit `source` is not found in `database`. Objects cannot be managed in these sources.",
+				Optional:            true,
+			},
 
 			"api_key": schema.StringAttribute{
 				MarkdownDescription: "API key for the basic authentication protocol. You cannot use API key Authentication along with any other authentication protocol.",
@@ -114,54 +202,236 @@ func (p *RipeDbProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "Skip unknown keys in validation.",
 				Optional:            true,
 			},
+
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of times a request is retried after a network error, a server error or rate limiting. Creations are only retried when rate limited, and the whois queries after a network error. Set to `0` to disable retries. Defaults to `%d`.", DefaultMaxRetries),
+				Optional:            true,
+			},
+			"retry_min_wait": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Minimum time to wait before retrying a request, in seconds. The wait doubles on every attempt, with some random jitter. Defaults to `%d`.", DefaultRetryMinWait),
+				Optional:            true,
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum time to wait before retrying a request, in seconds. A `Retry-After` header asking to wait longer than this gives up on the request. Defaults to `%d`.", DefaultRetryMaxWait),
+				Optional:            true,
+			},
+
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the RIPE database at the same time, whois queries included, shared by all the resources and data sources of the provider. Unlimited by default.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the RIPE database per second, whois queries included, shared by all the resources and data sources of the provider. Retries count towards this limit. Unlimited by default.",
+				Optional:            true,
+			},
+
+			"default_attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "Attributes added to every object managed by the provider, after the attributes of the object. An attribute set on the object overrides all the default attributes with the same name. The merged attributes are shown in the `attributes_all` attribute of the objects.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "the name of the attribute",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "the value of the attribute",
+							Required:            true,
+						},
+						"classes": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "the classes of the objects the attribute is added to. Defaults to all classes",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"ignore_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the attributes managed outside of Terraform, for instance `remarks` added by the registry or `mnt-routes` set by customers. Their values are kept as found in the database when an object is updated, in place, and are not reported as drift. The values given in the `attributes` of an object are managed alongside them. They are not added by `default_attributes`.",
+				Optional:            true,
+			},
+			"omit_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("Names of the server-managed attributes stripped from the objects read from the database. Defaults to `%s`.", strings.Join(OMIT_KEYS, "`, `")),
+				Optional:            true,
+			},
+			"append_source": schema.BoolAttribute{
+				MarkdownDescription: "Whether the `source` attribute is appended to the managed objects. When `false`, the `source` attribute must be given in the `attributes` of the objects, at the position of your choice, and is kept in their state. Defaults to `true`.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
 func (p *RipeDbProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// Values such as the API key may come from resources created in the same
+	// run. The client is only configured once they are known: until then,
+	// Terraform defers the resources and data sources when it supports it.
+	if !req.Config.Raw.IsFullyKnown() {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+		}
+
+		return
+	}
+
 	var data RipeDbProviderModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	userAgent := "terraform-provider-ripedb (https://github.com/frederic-arr/terraform-provider-ripedb)"
+	retry := RetryOptions{
+		MaxRetries: DefaultMaxRetries,
+		MinWait:    DefaultRetryMinWait * time.Second,
+		MaxWait:    DefaultRetryMaxWait * time.Second,
+	}
 
-	opts := ripedb.RipeClientOptions{
-		Endpoint:      data.Endpoint.ValueStringPointer(),
-		Source:        data.Source.ValueStringPointer(),
-		ApiKey:        data.ApiKey.ValueStringPointer(),
-		UserAgent:     &userAgent,
-		ExitOnWarning: data.ExitOnWarning.ValueBoolPointer(),
-		ExitOnInfo:    data.ExitOnInfo.ValueBoolPointer(),
-		ExitOnUnknown: data.ExitOnUnknown.ValueBoolPointer(),
-		DryRun:        data.DryRun.ValueBoolPointer(),
+	if !data.MaxRetries.IsNull() {
+		retry.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.RetryMinWait.IsNull() {
+		retry.MinWait = time.Duration(data.RetryMinWait.ValueInt64()) * time.Second
+	}
+	if !data.RetryMaxWait.IsNull() {
+		retry.MaxWait = time.Duration(data.RetryMaxWait.ValueInt64()) * time.Second
 	}
 
-	if !data.Certificate.IsNull() {
-		cert := []byte(data.Certificate.ValueString())
-		opts.Certificate = &cert
+	if retry.MaxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid retry configuration", "`max_retries` cannot be negative.")
 	}
-	if !data.Key.IsNull() {
-		key := []byte(data.Key.ValueString())
-		opts.Key = &key
+	if retry.MinWait < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_min_wait"), "Invalid retry configuration", "`retry_min_wait` cannot be negative.")
+	}
+	if retry.MaxWait < retry.MinWait {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_max_wait"), "Invalid retry configuration", "`retry_max_wait` cannot be lower than `retry_min_wait`.")
 	}
 
-	client, err := ripedb.NewRipeClient(&opts)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to create RIPE HTTP client", err.Error())
-		return
+	limit := LimitOptions{
+		MaxConcurrentRequests: int(data.MaxConcurrentRequests.ValueInt64()),
+		RequestsPerSecond:     data.RequestsPerSecond.ValueFloat64(),
 	}
 
-	client.SetSkipValidation(data.SkipValidation.ValueBool())
-	client.SetSkipUnknownKeys(data.IgnoreUnknownKeys.ValueBool())
+	if limit.MaxConcurrentRequests < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid rate limit configuration", "`max_concurrent_requests` cannot be negative.")
+	}
+	if limit.RequestsPerSecond < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("requests_per_second"), "Invalid rate limit configuration", "`requests_per_second` cannot be negative.")
+	}
+
+	environment := EnvironmentProd
+	if !data.Environment.IsNull() {
+		environment = data.Environment.ValueString()
+	}
+
+	if !slices.Contains(Environments, environment) {
+		resp.Diagnostics.AddAttributeError(path.Root("environment"), "Invalid environment", fmt.Sprintf("`environment` must be one of %v, got %q.", Environments, environment))
+	}
+	if data.RewriteHandles.ValueBool() && environment != EnvironmentTest {
+		resp.Diagnostics.AddAttributeError(path.Root("rewrite_handles"), "Invalid environment", fmt.Sprintf("`rewrite_handles` can only be used when `environment` is `%s`.", EnvironmentTest))
+	}
+	if !data.RewriteHandles.ValueBool() && !data.TestHandles.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("test_handles"), "Missing handle rewriting", "`test_handles` can only be used with `rewrite_handles`.")
+	}
+
+	backend := BackendRest
+	if !data.Backend.IsNull() {
+		backend = data.Backend.ValueString()
+	}
+
+	if !slices.Contains(Backends, backend) {
+		resp.Diagnostics.AddAttributeError(path.Root("backend"), "Invalid backend", fmt.Sprintf("`backend` must be one of %v, got %q.", Backends, backend))
+	}
+	if backend == BackendDump && data.DumpPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("dump_path"), "Missing dump path", fmt.Sprintf("`dump_path` is required when `backend` is `%s`.", BackendDump))
+	}
+	if backend != BackendDump && !data.DumpPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("dump_path"), "Invalid backend", fmt.Sprintf("`dump_path` can only be used when `backend` is `%s`.", BackendDump))
+	}
+	if backend != BackendWhois && !data.WhoisAddress.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("whois_address"), "Invalid backend", fmt.Sprintf("`whois_address` can only be used when `backend` is `%s`.", BackendWhois))
+	}
+
+	var grsSources []string
+	if !data.GrsSources.IsNull() {
+		resp.Diagnostics.Append(data.GrsSources.ElementsAs(ctx, &grsSources, false)...)
+	}
+
+	var defaultAttributes []DefaultAttribute
+	for _, a := range data.DefaultAttributes {
+		attr := DefaultAttribute{
+			Name:  a.Name.ValueString(),
+			Value: a.Value.ValueString(),
+		}
+
+		if !a.Classes.IsNull() {
+			resp.Diagnostics.Append(a.Classes.ElementsAs(ctx, &attr.Classes, false)...)
+		}
+
+		defaultAttributes = append(defaultAttributes, attr)
+	}
+
+	var ignoreAttributes []string
+	if !data.IgnoreAttributes.IsNull() {
+		resp.Diagnostics.Append(data.IgnoreAttributes.ElementsAs(ctx, &ignoreAttributes, false)...)
+	}
+
+	omitAttributes := slices.Clone(OMIT_KEYS)
+	if !data.OmitAttributes.IsNull() {
+		resp.Diagnostics.Append(data.OmitAttributes.ElementsAs(ctx, &omitAttributes, false)...)
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	_, _, source := environmentDefaults(environment)
+	if !data.Source.IsNull() {
+		source = data.Source.ValueString()
+	}
+
+	var client RipeDbClient
+	switch backend {
+	case BackendDump:
+		dumpClient, err := NewDumpClient(data.DumpPath.ValueString(), source)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("dump_path"), "Failed to open the RIPE database dumps", err.Error())
+			return
+		}
+
+		client = dumpClient
+	case BackendWhois:
+		address := DefaultWhoisAddress
+		if !data.WhoisAddress.IsNull() {
+			address = data.WhoisAddress.ValueString()
+		}
+
+		client = NewWhoisClient(&WhoisClientOptions{
+			Address: address,
+			Source:  source,
+			Retry:   retry,
+			Limit:   limit,
+		})
+	default:
+		restClient, err := newRestClient(&data, environment, retry, limit)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to create RIPE HTTP client", err.Error())
+			return
+		}
+
+		client = restClient
+	}
+
 	providerData := RipeDbProviderData{
-		Client: client,
+		Client:            client,
+		GrsSources:        grsSources,
+		DefaultAttributes: defaultAttributes,
+		IgnoreAttributes:  ignoreAttributes,
+		OmitAttributes:    omitAttributes,
+		AppendSource:      data.AppendSource.IsNull() || data.AppendSource.ValueBool(),
 	}
 	resp.DataSourceData = &providerData
 	resp.ResourceData = &providerData
@@ -176,6 +446,17 @@ func (p *RipeDbProvider) Resources(ctx context.Context) []func() resource.Resour
 func (p *RipeDbProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewObjectDataSource,
+		NewSearchDataSource,
+		NewInverseLookupDataSource,
+		NewObjectVersionsDataSource,
+		NewObjectVersionDataSource,
+		NewAbuseContactDataSource,
+		NewLessSpecificsDataSource,
+		NewMoreSpecificsDataSource,
+		NewFreeSpaceDataSource,
+		NewObjectsDataSource,
+		NewClassTemplateDataSource,
+		NewMaintainedObjectsDataSource,
 	}
 }
 
@@ -193,3 +474,46 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// newRestClient creates the client of the RIPE Database RESTful API from the
+// provider configuration.
+func newRestClient(data *RipeDbProviderModel, environment string, retry RetryOptions, limit LimitOptions) (*RestClient, error) {
+	opts := RestClientOptions{
+		Environment:    environment,
+		RewriteHandles: data.RewriteHandles.ValueBool(),
+		Endpoint:       data.Endpoint.ValueStringPointer(),
+		Source:         data.Source.ValueStringPointer(),
+		ApiKey:         data.ApiKey.ValueStringPointer(),
+		UserAgent:      "terraform-provider-ripedb (https://github.com/frederic-arr/terraform-provider-ripedb)",
+		ExitOnWarning:  data.ExitOnWarning.ValueBool(),
+		ExitOnInfo:     data.ExitOnInfo.ValueBool(),
+		ExitOnUnknown:  data.ExitOnUnknown.ValueBool(),
+		DryRun:         data.DryRun.ValueBool(),
+		Retry:          retry,
+		Limit:          limit,
+	}
+
+	for _, v := range data.TestHandles.Elements() {
+		if handle, ok := v.(types.String); ok {
+			opts.TestHandles = append(opts.TestHandles, handle.ValueString())
+		}
+	}
+
+	if !data.Certificate.IsNull() {
+		cert := []byte(data.Certificate.ValueString())
+		opts.Certificate = &cert
+	}
+	if !data.Key.IsNull() {
+		key := []byte(data.Key.ValueString())
+		opts.Key = &key
+	}
+
+	client, err := NewRestClient(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetSkipValidation(data.SkipValidation.ValueBool())
+	client.SetSkipUnknownKeys(data.IgnoreUnknownKeys.ValueBool())
+	return client, nil
+}
@@ -4,10 +4,19 @@
 package provider
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-ripedb/internal/ripetest"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -18,8 +27,130 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"ripedb": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccMntner is the maintainer of the objects created in the tests, whose
+// password is given by testAccApiKey.
+const (
+	testAccMntner   = "TEST-MNT"
+	testAccPassword = "hunter2"
+)
+
+var testAccApiKey = base64.StdEncoding.EncodeToString([]byte(testAccMntner + ":" + testAccPassword))
+
+// testAccServer starts an in-memory RIPE database holding the testAccMntner
+// maintainer, closed at the end of the test.
+func testAccServer(t *testing.T) *ripetest.Server {
+	srv := ripetest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.SetPassword(testAccMntner, testAccPassword)
+	err := srv.AddObject(DefaultTestSource, `
+mntner:  TEST-MNT
+auth:    MD5-PW $1$abcdefgh$0123456789abcdefghijkl
+mnt-by:  TEST-MNT
+source:  TEST
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return srv
+}
+
+// testAccProviderConfig configures the provider to use the in-memory database.
+func testAccProviderConfig(srv *ripetest.Server) string {
+	return fmt.Sprintf(`
+provider "ripedb" {
+  endpoint = %q
+  database = %q
+  api_key  = %q
+}
+`, srv.URL, DefaultTestSource, testAccApiKey)
+}
+
+// testReadDataSource runs the Read of a data source configured with the
+// client, the attributes missing from the values being null, and returns its
+// state.
+func testReadDataSource(t *testing.T, ds datasource.DataSource, client RipeDbClient, values map[string]tftypes.Value) tfsdk.State {
+	ctx := context.Background()
+	if c, ok := ds.(datasource.DataSourceWithConfigure); ok {
+		c.Configure(ctx, datasource.ConfigureRequest{ProviderData: &RipeDbProviderData{Client: client}}, &datasource.ConfigureResponse{})
+	}
+
+	var schemaResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	typ, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected schema type %T", schemaResp.Schema.Type().TerraformType(ctx))
+	}
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range typ.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if v, ok := values[name]; ok {
+			attributes[name] = v
+		}
+	}
+
+	raw := tftypes.NewValue(typ, attributes)
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw}}
+	ds.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.State
+}
+
+// testRestClient returns a REST client of the server authenticated as
+// testAccMntner.
+func testRestClient(t *testing.T, srv *ripetest.Server) *RestClient {
+	source := DefaultTestSource
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source, ApiKey: &testAccApiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestProviderConfigure_UnknownConfig(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), tftypes.UnknownValue),
+	}
+
+	for _, deferralAllowed := range []bool{true, false} {
+		req := provider.ConfigureRequest{
+			Config:             config,
+			ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: deferralAllowed},
+		}
+
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, req, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if resp.ResourceData != nil || resp.DataSourceData != nil {
+			t.Errorf("expected the client to be left unconfigured")
+		}
+
+		if deferralAllowed != (resp.Deferred != nil) {
+			t.Errorf("expected deferred to be %t, got %v", deferralAllowed, resp.Deferred)
+		}
+	}
+}
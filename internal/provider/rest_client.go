@@ -0,0 +1,676 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/frederic-arr/ripedb-go/ripedb/models"
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// generatedKeys are the attributes set by the RIPE database which must never
+// be sent in a create or update request.
+var generatedKeys = []string{"created", "last-modified", "dry-run"}
+
+// RestClientOptions configures a RestClient. Unset values fall back to the
+// defaults of the environment, the production RIPE database by default.
+type RestClientOptions struct {
+	Environment string
+	Endpoint    *string
+	Source      *string
+	UserAgent   string
+
+	// RewriteHandles rewrites the production handles and source to their
+	// TEST database equivalent, see toTestHandle.
+	RewriteHandles bool
+
+	// TestHandles are the handles of the TEST database which are not
+	// rewritten back to their production equivalent.
+	TestHandles []string
+
+	ApiKey *string
+
+	Certificate *[]byte
+	Key         *[]byte
+
+	ExitOnWarning bool
+	ExitOnInfo    bool
+	ExitOnUnknown bool
+	DryRun        bool
+
+	Retry RetryOptions
+	Limit LimitOptions
+}
+
+// RestClient talks to the RIPE Database RESTful API. It mirrors the request
+// logic of ripedb-go but owns its *http.Client so that requests can be
+// retried and carry the Terraform context.
+type RestClient struct {
+	endpoint  string
+	source    string
+	userAgent string
+	apiKey    *string
+
+	exitOnWarning bool
+	exitOnInfo    bool
+	exitOnUnknown bool
+	dryRun        bool
+
+	skipValidation  bool
+	skipUnknownKeys bool
+
+	// rewriter is nil unless the handles are rewritten.
+	rewriter *handleRewriter
+
+	httpClient *http.Client
+}
+
+// RequestError is returned when the RIPE database rejects a request.
+type RequestError struct {
+	StatusCode int
+	Messages   []string
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("RIPE database request error (HTTP %d): %v", e.StatusCode, e.Messages)
+}
+
+func NewRestClient(opts *RestClientOptions) (*RestClient, error) {
+	isUsingApiKeyAuth := opts.ApiKey != nil
+	isUsingX509Auth := opts.Certificate != nil || opts.Key != nil
+
+	if isUsingApiKeyAuth && *opts.ApiKey == "" {
+		return nil, fmt.Errorf("an empty API key was provided")
+	}
+
+	if isUsingApiKeyAuth {
+		if _, err := base64.StdEncoding.DecodeString(*opts.ApiKey); err != nil {
+			return nil, fmt.Errorf("API key is not in base64; be sure to put the whole '<username>:<password>' in base64")
+		}
+	}
+
+	if isUsingX509Auth && (opts.Certificate == nil || opts.Key == nil) {
+		return nil, fmt.Errorf("incomplete x.509 client authentication parameters")
+	}
+
+	if isUsingApiKeyAuth && isUsingX509Auth {
+		return nil, fmt.Errorf("cannot use multiple authentication protocols")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	endpoint, mtlsEndpoint, source := environmentDefaults(opts.Environment)
+	if isUsingX509Auth {
+		cert, err := tls.X509KeyPair(*opts.Certificate, *opts.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+		endpoint = mtlsEndpoint
+	}
+
+	if opts.Endpoint != nil {
+		endpoint = *opts.Endpoint
+	}
+
+	if opts.Source != nil {
+		source = *opts.Source
+	}
+
+	var rewriter *handleRewriter
+	if opts.RewriteHandles {
+		rewriter = newHandleRewriter(opts.TestHandles)
+	}
+
+	return &RestClient{
+		endpoint:      endpoint,
+		source:        source,
+		userAgent:     opts.UserAgent,
+		apiKey:        opts.ApiKey,
+		exitOnWarning: opts.ExitOnWarning,
+		exitOnInfo:    opts.ExitOnInfo,
+		exitOnUnknown: opts.ExitOnUnknown,
+		dryRun:        opts.DryRun,
+		rewriter:      rewriter,
+		httpClient: &http.Client{
+			Transport: newRetryTransport(newLimitTransport(transport, opts.Limit), opts.Retry),
+		},
+	}, nil
+}
+
+func (c *RestClient) GetEndpoint() string {
+	return c.endpoint
+}
+
+// GetSource returns the default source of the client, as the production
+// source when the handles are rewritten.
+func (c *RestClient) GetSource() string {
+	return c.incoming(c.source)
+}
+
+func (c *RestClient) GetSkipValidation() bool {
+	return c.skipValidation
+}
+
+func (c *RestClient) GetSkipUnknownKeys() bool {
+	return c.skipUnknownKeys
+}
+
+func (c *RestClient) SetSkipValidation(skipValidation bool) {
+	c.skipValidation = skipValidation
+}
+
+func (c *RestClient) SetSkipUnknownKeys(skipUnknownKeys bool) {
+	c.skipUnknownKeys = skipUnknownKeys
+}
+
+// GetObject looks up an object, unfiltered. An empty source means the default
+// source of the client.
+func (c *RestClient) GetObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error) {
+	return c.GetObjectWithOptions(ctx, source, resource, key, true)
+}
+
+// GetObjectWithOptions looks up an object. Without unfiltered, the database
+// removes the contact attributes and masks the `auth` values, as for an
+// anonymous lookup.
+func (c *RestClient) GetObjectWithOptions(ctx context.Context, source string, resource string, key string, unfiltered bool) (*rpsl.Object, error) {
+	q := url.Values{}
+	if unfiltered {
+		q = unfilteredQuery()
+	}
+
+	res, err := c.do(ctx, http.MethodGet, c.objectPath(c.outgoing(source), resource, c.outgoing(key)), q, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findOne(res)
+}
+
+func (c *RestClient) CreateObjectWithOptions(ctx context.Context, source string, resource string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error) {
+	data, err := prepareResource(resource, c.outgoingObject(object), skipValidation, skipUnknownKeys, skipKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.request(ctx, http.MethodPost, c.outgoing(source), resource, "", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findOne(res)
+}
+
+func (c *RestClient) UpdateObjectWithOptions(ctx context.Context, source string, resource string, key string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error) {
+	data, err := prepareResource(resource, c.outgoingObject(object), skipValidation, skipUnknownKeys, skipKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.request(ctx, http.MethodPut, c.outgoing(source), resource, c.outgoing(key), data)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findOne(res)
+}
+
+func (c *RestClient) DeleteObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error) {
+	res, err := c.do(ctx, http.MethodDelete, c.objectPath(c.outgoing(source), resource, c.outgoing(key)), url.Values{}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findOne(res)
+}
+
+// outgoing rewrites a handle or source sent to the database.
+func (c *RestClient) outgoing(value string) string {
+	if c.rewriter == nil {
+		return value
+	}
+
+	return c.rewriter.outgoing(value)
+}
+
+// incoming rewrites a handle or source returned by the database.
+func (c *RestClient) incoming(value string) string {
+	if c.rewriter == nil {
+		return value
+	}
+
+	return c.rewriter.incoming(value)
+}
+
+// outgoingObject rewrites the handles of an object sent to the database.
+func (c *RestClient) outgoingObject(obj *rpsl.Object) *rpsl.Object {
+	if c.rewriter == nil {
+		return obj
+	}
+
+	return rewriteObject(obj, c.rewriter.outgoing)
+}
+
+// findOne returns the single object of a response, with its handles
+// rewritten back when needed.
+func (c *RestClient) findOne(res *models.Resource) (*rpsl.Object, error) {
+	obj, err := findOne(res)
+	if err != nil || c.rewriter == nil {
+		return obj, err
+	}
+
+	return rewriteObject(obj, c.rewriter.incoming), nil
+}
+
+// Search runs a query with the search API. A query matching nothing returns
+// no objects rather than an error.
+func (c *RestClient) Search(ctx context.Context, opts SearchOptions) ([]*rpsl.Object, error) {
+	sources := opts.Sources
+	if len(sources) == 0 {
+		sources = []string{c.source}
+	}
+
+	q := url.Values{}
+	q.Set("query-string", c.outgoing(opts.QueryString))
+	for _, source := range sources {
+		q.Add("source", c.outgoing(source))
+	}
+
+	for _, typeFilter := range opts.TypeFilters {
+		q.Add("type-filter", typeFilter)
+	}
+
+	for _, inverseAttribute := range opts.InverseAttributes {
+		q.Add("inverse-attribute", inverseAttribute)
+	}
+
+	for _, flag := range opts.Flags {
+		q.Add("flags", flag)
+	}
+
+	if opts.Unfiltered {
+		q.Add("unfiltered", "")
+	}
+
+	res, err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/search", c.endpoint), q, nil, nil)
+	if isNotFound(err) {
+		return []*rpsl.Object{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []*rpsl.Object{}
+	if res.Objects == nil {
+		return objects, nil
+	}
+
+	for i := range res.Objects.Object {
+		obj, err := models.ModelObjectToRpslObject(&res.Objects.Object[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if c.rewriter != nil {
+			obj = rewriteObject(obj, c.rewriter.incoming)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// GetVersions returns the versions of an object, oldest first.
+func (c *RestClient) GetVersions(ctx context.Context, source string, resource string, key string) ([]ObjectVersion, error) {
+	versions := struct {
+		Versions *struct {
+			Version []ObjectVersion `json:"version"`
+		} `json:"versions"`
+	}{}
+
+	path := c.objectPath(c.outgoing(source), resource, c.outgoing(key)) + "/versions"
+	if _, err := c.do(ctx, http.MethodGet, path, url.Values{}, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	if versions.Versions == nil {
+		return []ObjectVersion{}, nil
+	}
+
+	return versions.Versions.Version, nil
+}
+
+// GetVersion returns an object as it was at the given revision, filtered
+// unless unfiltered is set.
+func (c *RestClient) GetVersion(ctx context.Context, source string, resource string, key string, revision int64, unfiltered bool) (*rpsl.Object, error) {
+	q := url.Values{}
+	if unfiltered {
+		q = unfilteredQuery()
+	}
+
+	path := fmt.Sprintf("%s/versions/%d", c.objectPath(c.outgoing(source), resource, c.outgoing(key)), revision)
+	res, err := c.do(ctx, http.MethodGet, path, q, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findOne(res)
+}
+
+// GetAbuseContact queries the abuse contact API, which is not bound to a
+// source.
+func (c *RestClient) GetAbuseContact(ctx context.Context, resource string) (*AbuseContact, error) {
+	contact := struct {
+		AbuseContacts *AbuseContact `json:"abuse-contacts"`
+	}{}
+
+	path := fmt.Sprintf("%s/abuse-contact/%s", c.endpoint, url.PathEscape(resource))
+	if _, err := c.do(ctx, http.MethodGet, path, url.Values{}, nil, &contact); err != nil {
+		return nil, err
+	}
+
+	if contact.AbuseContacts == nil || contact.AbuseContacts.Email == "" {
+		return nil, &RequestError{StatusCode: http.StatusNotFound, Messages: []string{fmt.Sprintf("No abuse contact found for %s", resource)}}
+	}
+
+	return contact.AbuseContacts, nil
+}
+
+// GetTemplate queries the metadata API for the template of a class.
+func (c *RestClient) GetTemplate(ctx context.Context, class string) (*ClassTemplate, error) {
+	templates := struct {
+		Templates *struct {
+			Template []struct {
+				Type       string `json:"type"`
+				Attributes struct {
+					Attribute []struct {
+						Name        string   `json:"name"`
+						Requirement string   `json:"requirement"`
+						Cardinality string   `json:"cardinality"`
+						Keys        []string `json:"keys"`
+					} `json:"attribute"`
+				} `json:"attributes"`
+			} `json:"template"`
+		} `json:"templates"`
+	}{}
+
+	path := fmt.Sprintf("%s/metadata/templates/%s", c.endpoint, url.PathEscape(class))
+	if _, err := c.do(ctx, http.MethodGet, path, url.Values{}, nil, &templates); err != nil {
+		return nil, err
+	}
+
+	if templates.Templates == nil || len(templates.Templates.Template) == 0 {
+		return nil, &RequestError{StatusCode: http.StatusNotFound, Messages: []string{fmt.Sprintf("No template found for %s", class)}}
+	}
+
+	template := &ClassTemplate{Class: templates.Templates.Template[0].Type, Attributes: []TemplateAttribute{}}
+	for _, a := range templates.Templates.Template[0].Attributes.Attribute {
+		template.Attributes = append(template.Attributes, TemplateAttribute{
+			Name:        a.Name,
+			Requirement: strings.ToLower(a.Requirement),
+			Cardinality: strings.ToLower(a.Cardinality),
+			PrimaryKey:  slices.Contains(a.Keys, "PRIMARY_KEY"),
+			LookupKey:   slices.Contains(a.Keys, "LOOKUP_KEY"),
+			InverseKey:  slices.Contains(a.Keys, "INVERSE_KEY"),
+		})
+	}
+
+	return template, nil
+}
+
+// request sends a lookup, creation or update of an object for the resources,
+// unfiltered: their state holds the contact attributes and the `auth` values
+// of the configuration, and is set from the response.
+func (c *RestClient) request(ctx context.Context, method string, source string, resource string, key string, data *models.Resource) (*models.Resource, error) {
+	return c.do(ctx, method, c.objectPath(source, resource, key), unfilteredQuery(), data, nil)
+}
+
+// unfilteredQuery returns the query parameters requesting the objects
+// unfiltered.
+func unfilteredQuery() url.Values {
+	return url.Values{"unfiltered": {""}}
+}
+
+// objectPath returns the URL of an object, or of its class when the key is
+// empty.
+func (c *RestClient) objectPath(source string, resource string, key string) string {
+	if source == "" {
+		source = c.source
+	}
+
+	path := fmt.Sprintf("%s/%s/%s", c.endpoint, source, resource)
+	if key != "" {
+		path = fmt.Sprintf("%s/%s", path, url.PathEscape(key))
+	}
+
+	return path
+}
+
+// do sends a request to the given URL with the query parameters, and parses
+// the response. The response is also decoded in extra when set, for the
+// fields which are not part of models.Resource.
+func (c *RestClient) do(ctx context.Context, method string, path string, q url.Values, data *models.Resource, extra any) (*models.Resource, error) {
+	ctx = newLogContext(ctx, c.secrets()...)
+
+	var body io.Reader
+	if data != nil {
+		buf, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != nil {
+		req.Header.Set("Authorization", fmt.Sprintf("Basic %s", *c.apiKey))
+	}
+
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.dryRun {
+		q.Add("dry-run", "")
+	}
+	req.URL.RawQuery = q.Encode()
+
+	fields := map[string]interface{}{
+		"method": method,
+		"url":    redactURL(req.URL),
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending RIPE database request", fields)
+	if data != nil {
+		tflog.SubsystemTrace(ctx, logSubsystem, "RIPE database request body", merge(fields, map[string]interface{}{
+			"body": redactedRpsl(data),
+		}))
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.SubsystemError(ctx, logSubsystem, "RIPE database request failed", merge(fields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	fields["status"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received RIPE database response", fields)
+
+	return c.parseResponse(ctx, resp, fields, extra)
+}
+
+// secrets returns the credentials which must be masked in the logs.
+func (c *RestClient) secrets() []string {
+	if c.apiKey == nil {
+		return nil
+	}
+
+	secrets := []string{*c.apiKey}
+	if decoded, err := base64.StdEncoding.DecodeString(*c.apiKey); err == nil {
+		secrets = append(secrets, string(decoded))
+		if _, password, ok := strings.Cut(string(decoded), ":"); ok {
+			secrets = append(secrets, password)
+		}
+	}
+
+	return secrets
+}
+
+func (c *RestClient) parseResponse(ctx context.Context, resp *http.Response, fields map[string]interface{}, extra any) (*models.Resource, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	res := &models.Resource{}
+	if err := json.Unmarshal(body, res); err != nil {
+		return nil, fmt.Errorf("failed to decode response (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	if extra != nil {
+		if err := json.Unmarshal(body, extra); err != nil {
+			return nil, fmt.Errorf("failed to decode response (HTTP %d): %w", resp.StatusCode, err)
+		}
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "RIPE database response body", merge(fields, map[string]interface{}{
+		"body": redactedRpsl(res),
+	}))
+
+	errs, warnings, infos, unknown := gatherMessages(res)
+	for _, msg := range errs {
+		tflog.SubsystemError(ctx, logSubsystem, msg, fields)
+	}
+
+	for _, msg := range warnings {
+		tflog.SubsystemWarn(ctx, logSubsystem, msg, fields)
+	}
+
+	for _, msg := range append(infos, unknown...) {
+		tflog.SubsystemInfo(ctx, logSubsystem, msg, fields)
+	}
+
+	if len(errs) > 0 {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Messages: errs}
+	}
+
+	if len(warnings) > 0 && c.exitOnWarning {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Messages: warnings}
+	}
+
+	if len(infos) > 0 && c.exitOnInfo {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Messages: infos}
+	}
+
+	if len(unknown) > 0 && c.exitOnUnknown {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Messages: unknown}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Messages: []string{http.StatusText(resp.StatusCode)}}
+	}
+
+	return res, nil
+}
+
+// gatherMessages sorts the error messages of a response by severity.
+func gatherMessages(res *models.Resource) ([]string, []string, []string, []string) {
+	errs := []string{}
+	warnings := []string{}
+	infos := []string{}
+	unknown := []string{}
+	if res.ErrorMessages == nil {
+		return errs, warnings, infos, unknown
+	}
+
+	for _, m := range res.ErrorMessages.ErrorMessage {
+		if m.Text == nil {
+			continue
+		}
+
+		args := make([]interface{}, len(m.Args))
+		for i, arg := range m.Args {
+			args[i] = arg.Value
+		}
+
+		msg := fmt.Sprintf(*m.Text, args...)
+		if m.Severity == nil {
+			unknown = append(unknown, msg)
+			continue
+		}
+
+		switch *m.Severity {
+		case "Error":
+			errs = append(errs, msg)
+		case "Warning":
+			warnings = append(warnings, msg)
+		case "Info":
+			infos = append(infos, msg)
+		default:
+			unknown = append(unknown, msg)
+		}
+	}
+
+	return errs, warnings, infos, unknown
+}
+
+func prepareResource(resource string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*models.Resource, error) {
+	data := models.NewResourceFromRpslObject(object)
+	data.RemoveKeys(generatedKeys)
+
+	if !skipValidation {
+		skipKeys = append(slices.Clone(skipKeys), generatedKeys...)
+		if err := models.ValidateResourceWithOptions(resource, data, skipUnknownKeys, skipKeys); err != nil {
+			return nil, err
+		}
+	}
+
+	return &data, nil
+}
+
+// merge returns a copy of the log fields with the extra fields added.
+func merge(fields map[string]interface{}, extra map[string]interface{}) map[string]interface{} {
+	merged := maps.Clone(fields)
+	maps.Copy(merged, extra)
+	return merged
+}
+
+func findOne(res *models.Resource) (*rpsl.Object, error) {
+	obj, err := res.FindOne()
+	if err != nil {
+		return nil, err
+	}
+
+	return models.ModelObjectToRpslObject(obj)
+}
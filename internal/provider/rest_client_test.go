@@ -0,0 +1,366 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/frederic-arr/rpsl-go"
+)
+
+func TestRestClient_Search(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/search" {
+			t.Errorf("expected the search API to be queried, got %s", r.URL.Path)
+		}
+
+		if !slices.Equal(q["source"], []string{"RIPE"}) || !slices.Equal(q["inverse-attribute"], []string{"mnt-by"}) || !slices.Equal(q["type-filter"], []string{"person", "role"}) {
+			t.Errorf("unexpected query parameters: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if q.Get("query-string") == "NOTHING-MNT" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errormessages": {"errormessage": [{"severity": "Error", "text": "ERROR:101: no entries found"}]}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"objects": {"object": [
+			{"attributes": {"attribute": [{"name": "person", "value": "John Smith"}, {"name": "nic-hdl", "value": "JS1-RIPE"}]}},
+			{"attributes": {"attribute": [{"name": "role", "value": "Operations"}, {"name": "nic-hdl", "value": "OPS1-RIPE"}]}}
+		]}}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := SearchOptions{QueryString: "EXAMPLE-MNT", InverseAttributes: []string{"mnt-by"}, TypeFilters: []string{"person", "role"}}
+	objects, err := client.Search(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 || objects[1].Attributes[0].Value != "Operations" {
+		t.Fatalf("expected the person and the role, got %v", objects)
+	}
+
+	opts.QueryString = "NOTHING-MNT"
+	objects, err = client.Search(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 0 {
+		t.Fatalf("expected no objects, got %v", objects)
+	}
+}
+
+func TestRestClient_ObjectLifecycle(t *testing.T) {
+	ctx := context.Background()
+	srv := testAccServer(t)
+
+	source := DefaultTestSource
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source, ApiKey: &testAccApiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	person, _ := rpsl.Parse("person: John Smith\nnic-hdl: JS1-TEST\naddress: ACME, Inc.\nphone: +0\ne-mail: john@example.net\nmnt-by: TEST-MNT\nsource: TEST\n")
+	created, err := client.CreateObjectWithOptions(ctx, "", "person", person, false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.GetFirst("created") == nil {
+		t.Errorf("expected the created attribute to be set, got %v", created)
+	}
+
+	person.Attributes[3].Value = "+1"
+	if _, err := client.UpdateObjectWithOptions(ctx, "", "person", "JS1-TEST", person, false, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := client.GetObject(ctx, "", "person", "JS1-TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if phone := current.GetFirst("phone"); phone == nil || *phone != "+1" {
+		t.Errorf("expected the phone to be updated, got %v", current)
+	}
+
+	if current.GetFirst("e-mail") == nil {
+		t.Errorf("expected the lookup to be unfiltered, got %v", current)
+	}
+
+	filtered, err := client.GetObjectWithOptions(ctx, "", "person", "JS1-TEST", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filtered.GetFirst("e-mail") != nil {
+		t.Errorf("expected the e-mail to be filtered, got %v", filtered)
+	}
+
+	objects, err := client.Search(ctx, SearchOptions{QueryString: "JS1-TEST", Unfiltered: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 1 || objects[0].GetFirst("e-mail") == nil {
+		t.Errorf("expected the search to be unfiltered, got %v", objects)
+	}
+
+	mntner, err := client.GetObject(ctx, "", "mntner", testAccMntner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth := mntner.GetFirst("auth"); auth == nil || *auth == "MD5-PW" {
+		t.Errorf("expected the auth to be unfiltered for the authenticated maintainer, got %v", mntner)
+	}
+
+	mntner, err = client.GetObjectWithOptions(ctx, "", "mntner", testAccMntner, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth := mntner.GetFirst("auth"); auth == nil || *auth != "MD5-PW" {
+		t.Errorf("expected the auth to be filtered without unfiltered, got %v", mntner)
+	}
+
+	if _, err := client.DeleteObject(ctx, "", "person", "JS1-TEST"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetObject(ctx, "", "person", "JS1-TEST"); !isNotFound(err) {
+		t.Errorf("expected the person to be deleted, got %v", err)
+	}
+}
+
+func TestRestClient_RewriteHandles(t *testing.T) {
+	ctx := context.Background()
+	srv := testAccServer(t)
+
+	source := DefaultTestSource
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source, ApiKey: &testAccApiKey, RewriteHandles: true, TestHandles: []string{"FOO-TEST"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.GetSource() != DefaultSource {
+		t.Errorf("expected the source to be rewritten back, got %q", client.GetSource())
+	}
+
+	person, _ := rpsl.Parse("person: John Smith\nnic-hdl: JS1-RIPE\nremarks: FOO-TEST\nadmin-c: AB1-TEST\nmnt-by: TEST-MNT\nsource: RIPE\n")
+	created, err := client.CreateObjectWithOptions(ctx, client.GetSource(), "person", person, true, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range map[string]string{"nic-hdl": "JS1-RIPE", "remarks": "FOO-TEST", "admin-c": "AB1-RIPE", "source": DefaultSource} {
+		if value := created.GetFirst(name); value == nil || *value != expected {
+			t.Errorf("expected the %s to be %q, got %v", name, expected, created)
+		}
+	}
+
+	// A new client rewrites back the handles it never sent.
+	fresh, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source, ApiKey: &testAccApiKey, RewriteHandles: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	looked, err := fresh.GetObject(ctx, fresh.GetSource(), "person", "JS1-RIPE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value := looked.GetFirst("admin-c"); value == nil || *value != "AB1-RIPE" {
+		t.Errorf("expected the admin-c to be rewritten back, got %v", looked)
+	}
+
+	plain, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := plain.GetObject(ctx, "", "person", "JS1-TEST"); err != nil {
+		t.Errorf("expected the person to be created with the TEST handle, got %v", err)
+	}
+}
+
+func TestRestClient_Unauthorized(t *testing.T) {
+	ctx := context.Background()
+	srv := testAccServer(t)
+
+	source := DefaultTestSource
+	apiKey := base64.StdEncoding.EncodeToString([]byte(testAccMntner + ":wrong"))
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source, ApiKey: &apiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	person, _ := rpsl.Parse("person: John Smith\nnic-hdl: JS1-TEST\naddress: ACME, Inc.\nphone: +0\ne-mail: john@example.net\nmnt-by: TEST-MNT\nsource: TEST\n")
+	_, err = client.CreateObjectWithOptions(ctx, "", "person", person, false, false, nil)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected an authorization error, got %v", err)
+	}
+
+	if !strings.Contains(reqErr.Messages[0], "not authenticated by: TEST-MNT") {
+		t.Errorf("expected the error message to be formatted, got %q", reqErr.Messages[0])
+	}
+
+	mntner, err := client.GetObject(ctx, "", "mntner", testAccMntner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth := mntner.GetFirst("auth"); auth == nil || *auth != "MD5-PW" {
+		t.Errorf("expected the auth to be filtered, got %v", mntner)
+	}
+}
+
+func TestRestClient_Versions(t *testing.T) {
+	ctx := context.Background()
+	srv := testAccServer(t)
+
+	source := DefaultTestSource
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL, Source: &source, ApiKey: &testAccApiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	route, _ := rpsl.Parse("route: 192.0.2.0/24\norigin: AS64496\nmnt-by: TEST-MNT\nsource: TEST\n")
+	if _, err := client.CreateObjectWithOptions(ctx, "", "route", route, true, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	route.Attributes = append(route.Attributes[:3], rpsl.Attribute{Name: "remarks", Value: "updated"}, route.Attributes[3])
+	if _, err := client.UpdateObjectWithOptions(ctx, "", "route", "192.0.2.0/24AS64496", route, true, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := client.GetVersions(ctx, "", "route", "192.0.2.0/24AS64496")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(versions) != 2 || versions[1].Revision != 2 || versions[1].Operation != "ADD/UPD" {
+		t.Fatalf("expected 2 versions, got %v", versions)
+	}
+
+	first, err := client.GetVersion(ctx, "", "route", "192.0.2.0/24AS64496", 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Exists("remarks") {
+		t.Errorf("expected the first revision not to have remarks, got %v", first)
+	}
+
+	if _, err := client.GetVersion(ctx, "", "route", "192.0.2.0/24AS64496", 3, true); !isNotFound(err) {
+		t.Errorf("expected revision 3 not to be found, got %v", err)
+	}
+}
+
+func TestRestClient_AbuseContact(t *testing.T) {
+	ctx := context.Background()
+	srv := testAccServer(t)
+	for _, obj := range []string{
+		"role: Abuse\nnic-hdl: AB1-TEST\nabuse-mailbox: abuse@example.net\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"role: Security\nnic-hdl: SEC1-TEST\nabuse-mailbox: security@example.net\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"organisation: ORG-EX1-TEST\nabuse-c: AB1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.255\norg: ORG-EX1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.128 - 192.0.2.255\nabuse-c: SEC1-TEST\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"aut-num: AS64496\nmnt-by: TEST-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for resource, expected := range map[string]string{
+		"192.0.2.1":               "abuse@example.net",
+		"192.0.2.0/24":            "abuse@example.net",
+		"192.0.2.129":             "security@example.net",
+		"192.0.2.128/25":          "security@example.net",
+		"192.0.2.0 - 192.0.2.127": "abuse@example.net",
+	} {
+		contact, err := client.GetAbuseContact(ctx, resource)
+		if err != nil {
+			t.Fatalf("%s: %v", resource, err)
+		}
+
+		if contact.Email != expected {
+			t.Errorf("%s: expected %s, got %v", resource, expected, contact)
+		}
+	}
+
+	for _, resource := range []string{"AS64496", "198.51.100.1"} {
+		if _, err := client.GetAbuseContact(ctx, resource); !isNotFound(err) {
+			t.Errorf("%s: expected no abuse contact, got %v", resource, err)
+		}
+	}
+}
+
+func TestRestClient_Template(t *testing.T) {
+	ctx := context.Background()
+	srv := testAccServer(t)
+	if err := srv.AddTemplate("route", `
+		route:          mandatory   single     primary/lookup key
+		descr:          optional    multiple
+		origin:         mandatory   single     primary/inverse key
+		mnt-by:         mandatory   multiple   inverse key
+		created:        generated   single
+		source:         mandatory   single
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewRestClient(&RestClientOptions{Endpoint: &srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := client.GetTemplate(ctx, "route")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []TemplateAttribute{
+		{Name: "route", Requirement: "mandatory", Cardinality: "single", PrimaryKey: true, LookupKey: true},
+		{Name: "descr", Requirement: "optional", Cardinality: "multiple"},
+		{Name: "origin", Requirement: "mandatory", Cardinality: "single", PrimaryKey: true, InverseKey: true},
+		{Name: "mnt-by", Requirement: "mandatory", Cardinality: "multiple", InverseKey: true},
+		{Name: "created", Requirement: "generated", Cardinality: "single"},
+		{Name: "source", Requirement: "mandatory", Cardinality: "single"},
+	}
+
+	if template.Class != "route" || !slices.Equal(template.Attributes, expected) {
+		t.Errorf("unexpected template: %+v", template)
+	}
+
+	var reqErr *RequestError
+	if _, err := client.GetTemplate(ctx, "nothing"); !errors.As(err, &reqErr) || reqErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected an invalid object type error, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryOptions configures how failed requests are retried.
+type RetryOptions struct {
+	MaxRetries int
+	MinWait    time.Duration
+	MaxWait    time.Duration
+}
+
+// retryTransport retries requests which failed because of a network error,
+// a server error or rate limiting. Non-idempotent requests (POST) are only
+// retried when the server explicitly rejected them with 429 Too Many Requests,
+// as the object may otherwise already have been created.
+type retryTransport struct {
+	next http.RoundTripper
+	opts RetryOptions
+
+	// sleep is replaced in tests to avoid waiting.
+	sleep func(req *http.Request, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, opts RetryOptions) *retryTransport {
+	return &retryTransport{
+		next:  next,
+		opts:  opts,
+		sleep: sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		// A round tripper must not modify the request of the caller, each
+		// retry sends a copy with a fresh body.
+		r := req
+		if attempt > 0 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+
+				r.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		if attempt >= t.opts.MaxRetries || !shouldRetry(req, resp, err) || !replayable(req) {
+			return resp, err
+		}
+
+		wait := backoff(t.opts.MinWait, t.opts.MaxWait, attempt)
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"url":     redactURL(req.URL),
+			"attempt": attempt + 1,
+		}
+
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			if after, ok := retryAfter(resp); ok {
+				if after > t.opts.MaxWait {
+					// The server asked us to come back later than we are
+					// willing to wait, give up now instead of hammering it.
+					return resp, nil
+				}
+
+				wait = after
+			}
+
+			// The response is discarded, release its connection.
+			_ = resp.Body.Close()
+		}
+
+		fields["wait"] = wait.String()
+		tflog.SubsystemWarn(ctx, logSubsystem, "Retrying RIPE database request", fields)
+
+		if err := t.sleep(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// replayable reports whether the body of the request, if any, can be sent
+// again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Never retry a request which was cancelled by Terraform.
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the exponential backoff for the given attempt, capped at
+// maxWait, with jitter spreading the wait between its half and its full value,
+// but never below minWait.
+func backoff(minWait time.Duration, maxWait time.Duration, attempt int) time.Duration {
+	wait := minWait << attempt
+	if wait > maxWait || wait <= 0 {
+		wait = maxWait
+	}
+
+	lower := max(wait/2, min(minWait, wait))
+	if wait-lower <= 0 {
+		return wait
+	}
+
+	return lower + rand.N(wait-lower+1)
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(req *http.Request, d time.Duration) error {
+	return waitContext(req.Context(), d)
+}
+
+// waitContext waits for the duration, or until the context is cancelled.
+func waitContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
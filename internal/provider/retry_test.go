@@ -0,0 +1,162 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRetryTransport(opts RetryOptions, waits *[]time.Duration) *retryTransport {
+	t := newRetryTransport(http.DefaultTransport, opts)
+	t.sleep = func(_ *http.Request, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+
+	return t
+}
+
+func TestRetryTransport_RetriesServerErrors(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("attempt %d: expected the body to be replayed, got %q", calls, body)
+		}
+
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryOptions{MaxRetries: 3, MinWait: time.Second, MaxWait: 30 * time.Second}, &waits)}
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader("payload"))
+	body := req.Body
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Body != body {
+		t.Error("expected the body of the request not to be replaced")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if calls != 3 || len(waits) != 2 {
+		t.Fatalf("expected 3 calls and 2 waits, got %d calls and %d waits", calls, len(waits))
+	}
+
+	if waits[0] != time.Second {
+		t.Errorf("expected the first wait to be 1s, got %s", waits[0])
+	}
+
+	if waits[1] < time.Second || waits[1] > 2*time.Second {
+		t.Errorf("expected the second wait to be between 1s and 2s, got %s", waits[1])
+	}
+}
+
+func TestRetryTransport_HonorsRetryAfter(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryOptions{MaxRetries: 3, MinWait: time.Second, MaxWait: 30 * time.Second}, &waits)}
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", resp.StatusCode)
+	}
+
+	if len(waits) != 1 || waits[0] != 7*time.Second {
+		t.Fatalf("expected a single wait of 7s, got %v", waits)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryCreations(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryOptions{MaxRetries: 3, MinWait: time.Second, MaxWait: 30 * time.Second}, &waits)}
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusInternalServerError || calls != 1 {
+		t.Fatalf("expected a single failed call, got %d calls with status %d", calls, resp.StatusCode)
+	}
+}
+
+func TestRetryTransport_GivesUp(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryOptions{MaxRetries: 2, MinWait: time.Second, MaxWait: 30 * time.Second}, &waits)}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != 3 {
+		t.Fatalf("expected 3 failed calls, got %d calls with status %d", calls, resp.StatusCode)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		minWait  time.Duration
+		maxWait  time.Duration
+		attempt  int
+		min, max time.Duration
+	}{
+		{time.Second, 30 * time.Second, 0, time.Second, time.Second},
+		{time.Second, 30 * time.Second, 3, 4 * time.Second, 8 * time.Second},
+		{time.Second, 30 * time.Second, 10, 15 * time.Second, 30 * time.Second},
+		{20 * time.Second, 30 * time.Second, 1, 20 * time.Second, 30 * time.Second},
+		{time.Minute, 30 * time.Second, 0, 30 * time.Second, 30 * time.Second},
+	} {
+		for range 100 {
+			if wait := backoff(tc.minWait, tc.maxWait, tc.attempt); wait < tc.min || wait > tc.max {
+				t.Fatalf("backoff(%s, %s, %d): expected a wait between %s and %s, got %s", tc.minWait, tc.maxWait, tc.attempt, tc.min, tc.max, wait)
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SearchDataSource{}
+
+func NewSearchDataSource() datasource.DataSource {
+	return &SearchDataSource{}
+}
+
+type SearchDataSource struct {
+	client RipeDbClient
+}
+
+type SearchDataSourceModel struct {
+	Id               types.String            `tfsdk:"id"`
+	QueryString      types.String            `tfsdk:"query_string"`
+	TypeFilter       types.List              `tfsdk:"type_filter"`
+	InverseAttribute types.List              `tfsdk:"inverse_attribute"`
+	Flags            types.List              `tfsdk:"flags"`
+	Sources          types.List              `tfsdk:"sources"`
+	Unfiltered       types.Bool              `tfsdk:"unfiltered"`
+	MaskSensitive    types.Bool              `tfsdk:"mask_sensitive"`
+	Objects          []UnfilteredObjectModel `tfsdk:"objects"`
+}
+
+func (d *SearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+func (d *SearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	objectsSchema := objectsAttribute("the objects matching the query")
+	objectsSchema.NestedObject.Attributes["sensitive_attributes"] = sensitiveAttributesAttribute()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source runs a query with the search API of the RIPE Database, e.g. to find all the `route` objects of an origin.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the query string",
+				Computed:            true,
+			},
+			"query_string": schema.StringAttribute{
+				MarkdownDescription: "the query string, e.g. a primary key, a prefix or, with `inverse_attribute`, the value of an attribute",
+				Required:            true,
+			},
+			"type_filter": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the classes of the objects to return, e.g. `route`",
+				Optional:            true,
+			},
+			"inverse_attribute": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the attributes whose value is the query string, e.g. `origin` or `mnt-by`",
+				Optional:            true,
+			},
+			"flags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the query flags, with or without a leading dash, e.g. `r` to disable the recursive lookup of the referenced objects, `M` and `m` for the more specific objects, `l` and `L` for the less specific objects, or `B` for unfiltered objects. Prefer `unfiltered`, which masks the sensitive values",
+				Optional:            true,
+			},
+			"sources": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "the sources to search. Defaults to the `database` of the provider",
+				Optional:            true,
+			},
+			"unfiltered":     unfilteredAttribute(),
+			"mask_sensitive": maskSensitiveAttribute(),
+			"objects":        objectsSchema,
+		},
+	}
+}
+
+func (d *SearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data SearchDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := SearchOptions{QueryString: data.QueryString.ValueString(), Unfiltered: data.Unfiltered.ValueBool()}
+	resp.Diagnostics.Append(listToStrings(ctx, data.TypeFilter, &opts.TypeFilters)...)
+	resp.Diagnostics.Append(listToStrings(ctx, data.InverseAttribute, &opts.InverseAttributes)...)
+	resp.Diagnostics.Append(listToStrings(ctx, data.Flags, &opts.Flags)...)
+	resp.Diagnostics.Append(listToStrings(ctx, data.Sources, &opts.Sources)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, flag := range opts.Flags {
+		opts.Flags[i] = strings.TrimLeft(flag, "-")
+	}
+
+	objects, err := d.client.Search(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to search RIPE database", err.Error())
+		return
+	}
+
+	data.Id = data.QueryString
+	data.Objects = unfilteredObjectsToModels(objects, masksSensitive(data.Unfiltered, data.MaskSensitive))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listToStrings sets the target to the elements of a list of strings, or
+// leaves it untouched when the list is null or unknown.
+func listToStrings(ctx context.Context, list types.List, target *[]string) diag.Diagnostics {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	return list.ElementsAs(ctx, target, false)
+}
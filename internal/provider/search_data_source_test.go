@@ -0,0 +1,64 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSearchDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	for _, route := range []string{
+		"route: 193.0.0.0/21\norigin: AS3333\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"route: 193.0.10.0/23\norigin: AS3333\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"route: 192.0.2.0/24\norigin: AS64496\nmnt-by: TEST-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, route); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_search" "test" {
+					query_string      = "AS3333"
+					type_filter       = ["route"]
+					inverse_attribute = ["origin"]
+					flags             = ["-r"]
+				}
+
+				data "ripedb_search" "none" {
+					query_string      = "AS64511"
+					inverse_attribute = ["origin"]
+				}
+
+				data "ripedb_search" "unfiltered" {
+					query_string = "TEST-MNT"
+					type_filter  = ["mntner"]
+					flags        = ["r"]
+					unfiltered   = true
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_search.test", "objects.#", "2"),
+					resource.TestCheckResourceAttr("data.ripedb_search.test", "objects.0.id", "route:193.0.0.0/21AS3333"),
+					resource.TestCheckResourceAttr("data.ripedb_search.test", "objects.0.class", "route"),
+					resource.TestCheckResourceAttr("data.ripedb_search.test", "objects.0.value", "193.0.0.0/21AS3333"),
+					resource.TestCheckResourceAttr("data.ripedb_search.test", "objects.0.source", DefaultTestSource),
+					resource.TestCheckResourceAttr("data.ripedb_search.none", "objects.#", "0"),
+					resource.TestCheckResourceAttr("data.ripedb_search.test", "objects.0.sensitive_attributes.#", "0"),
+					resource.TestCheckResourceAttr("data.ripedb_search.unfiltered", "objects.0.attributes.1.value", redacted),
+					resource.TestCheckResourceAttr("data.ripedb_search.unfiltered", "objects.0.sensitive_attributes.0.name", "auth"),
+					resource.TestCheckResourceAttr("data.ripedb_search.unfiltered", "objects.0.sensitive_attributes.0.value", "MD5-PW $1$abcdefgh$0123456789abcdefghijkl"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,247 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-ripedb/internal/iprange"
+)
+
+var _ datasource.DataSource = &SpecificsDataSource{}
+
+// NewLessSpecificsDataSource returns the `ripedb_less_specifics` data source,
+// for the parent chain of a range.
+func NewLessSpecificsDataSource() datasource.DataSource {
+	return &SpecificsDataSource{less: true}
+}
+
+// NewMoreSpecificsDataSource returns the `ripedb_more_specifics` data source,
+// for the ranges below a range.
+func NewMoreSpecificsDataSource() datasource.DataSource {
+	return &SpecificsDataSource{}
+}
+
+// SpecificsDataSource looks up the address space objects less or more
+// specific than a range.
+type SpecificsDataSource struct {
+	client RipeDbClient
+	less   bool
+}
+
+type SpecificsDataSourceModel struct {
+	Id        types.String        `tfsdk:"id"`
+	Class     types.String        `tfsdk:"class"`
+	Value     types.String        `tfsdk:"value"`
+	AllLevels types.Bool          `tfsdk:"all_levels"`
+	Source    types.String        `tfsdk:"source"`
+	Objects   []AddressSpaceModel `tfsdk:"objects"`
+}
+
+// AddressSpaceModel is an inetnum, inet6num, route or route6 object with its
+// range and the attributes most used for planning.
+type AddressSpaceModel struct {
+	ObjectModel
+	FirstAddress types.String `tfsdk:"first_address"`
+	LastAddress  types.String `tfsdk:"last_address"`
+	Status       types.String `tfsdk:"status"`
+	MntBy        types.List   `tfsdk:"mnt_by"`
+}
+
+func (d *SpecificsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	if d.less {
+		resp.TypeName = req.ProviderTypeName + "_less_specifics"
+		return
+	}
+
+	resp.TypeName = req.ProviderTypeName + "_more_specifics"
+}
+
+func (d *SpecificsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	description := "This data source returns the objects more specific than an address range in the RIPE Database, e.g. the assignments made within an allocation."
+	levels := "whether to return all the more specific objects (`-M`) rather than only the first level below the range (`-m`). Defaults to `false`"
+	objects := "the more specific objects, sorted by first address, then less specific first"
+	if d.less {
+		description = "This data source returns the objects less specific than an address range in the RIPE Database, e.g. the parent chain of an assignment up to its allocation."
+		levels = "whether to return the whole parent chain and the exact match (`-L`) rather than only the first level above the range (`-l`). Defaults to `true`"
+		objects = "the less specific objects, sorted from the least specific"
+	}
+
+	objectsSchema := objectsAttribute(objects)
+	objectsSchema.NestedObject.Attributes["first_address"] = schema.StringAttribute{
+		MarkdownDescription: "the first address of the range of the object",
+		Computed:            true,
+	}
+	objectsSchema.NestedObject.Attributes["last_address"] = schema.StringAttribute{
+		MarkdownDescription: "the last address of the range of the object",
+		Computed:            true,
+	}
+	objectsSchema.NestedObject.Attributes["status"] = schema.StringAttribute{
+		MarkdownDescription: "the status of the object, unset for the route and route6 objects",
+		Computed:            true,
+	}
+	objectsSchema.NestedObject.Attributes["mnt_by"] = schema.ListAttribute{
+		ElementType:         types.StringType,
+		MarkdownDescription: "the maintainers of the object",
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "the ID of the range, as `class:value`",
+				Computed:            true,
+			},
+			"class": schema.StringAttribute{
+				MarkdownDescription: "the class of the objects, one of `inetnum`, `inet6num`, `route` or `route6`",
+				Required:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "the range, as an address, a prefix or `first - last`, e.g. `192.0.2.0/24` or `192.0.2.0 - 192.0.2.255`",
+				Required:            true,
+			},
+			"all_levels": schema.BoolAttribute{
+				MarkdownDescription: levels,
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "the source of the objects. Defaults to the `database` of the provider",
+				Optional:            true,
+			},
+			"objects": objectsSchema,
+		},
+	}
+}
+
+func (d *SpecificsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*RipeDbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RipeDbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *SpecificsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if unconfiguredClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data SpecificsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	class := data.Class.ValueString()
+	if !slices.Contains(iprange.Classes, class) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("class"),
+			"Invalid class",
+			fmt.Sprintf("The class must be one of %s, got %q.", strings.Join(iprange.Classes, ", "), class),
+		)
+
+		return
+	}
+
+	if _, err := iprange.Parse(data.Value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid range", err.Error())
+		return
+	}
+
+	opts := SearchOptions{
+		QueryString: data.Value.ValueString(),
+		TypeFilters: []string{class},
+		Flags:       []string{"r", d.flag(data.AllLevels)},
+	}
+
+	if !data.Source.IsNull() {
+		opts.Sources = []string{data.Source.ValueString()}
+	}
+
+	objects, err := d.client.Search(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to search RIPE database", err.Error())
+		return
+	}
+
+	// The database returns the objects in its own order.
+	slices.SortStableFunc(objects, compareObjectRanges)
+
+	data.Id = types.StringValue(fmt.Sprintf("%s:%s", class, data.Value.ValueString()))
+	data.Objects = []AddressSpaceModel{}
+	for i, m := range objectsToModels(objects) {
+		obj := AddressSpaceModel{
+			ObjectModel:  m,
+			FirstAddress: types.StringNull(),
+			LastAddress:  types.StringNull(),
+			Status:       types.StringPointerValue(objects[i].GetFirst("status")),
+		}
+
+		if r, err := iprange.ObjectRange(objects[i]); err == nil {
+			obj.FirstAddress = types.StringValue(r.First.String())
+			obj.LastAddress = types.StringValue(r.Last.String())
+		}
+
+		var diags diag.Diagnostics
+		obj.MntBy, diags = types.ListValueFrom(ctx, types.StringType, objects[i].GetAll("mnt-by"))
+		resp.Diagnostics.Append(diags...)
+		data.Objects = append(data.Objects, obj)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// compareObjectRanges orders the objects by range, less specific first, and
+// the objects without a range last.
+func compareObjectRanges(a *rpsl.Object, b *rpsl.Object) int {
+	ra, errA := iprange.ObjectRange(a)
+	rb, errB := iprange.ObjectRange(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+
+	return ra.Compare(rb)
+}
+
+// flag returns the query flag for the level of the lookup.
+func (d *SpecificsDataSource) flag(allLevels types.Bool) string {
+	switch {
+	case d.less && allLevels.ValueBool(), d.less && allLevels.IsNull():
+		return "L"
+	case d.less:
+		return "l"
+	case allLevels.ValueBool():
+		return "M"
+	default:
+		return "m"
+	}
+}
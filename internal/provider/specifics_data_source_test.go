@@ -0,0 +1,102 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSpecificsDataSources(t *testing.T) {
+	srv := testAccServer(t)
+	for _, obj := range []string{
+		"inetnum: 192.0.0.0 - 192.0.255.255\nstatus: ALLOCATED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.255\nstatus: SUB-ALLOCATED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.127\nstatus: ASSIGNED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.7\nstatus: ASSIGNED PA\nmnt-by: TEST-MNT\nsource: TEST\n",
+	} {
+		if err := srv.AddObject(DefaultTestSource, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+				data "ripedb_less_specifics" "test" {
+					class = "inetnum"
+					value = "192.0.2.0 - 192.0.2.127"
+				}
+
+				data "ripedb_more_specifics" "test" {
+					class = "inetnum"
+					value = "192.0.2.0/24"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ripedb_less_specifics.test", "objects.#", "3"),
+					resource.TestCheckResourceAttr("data.ripedb_less_specifics.test", "objects.0.status", "ALLOCATED PA"),
+					resource.TestCheckResourceAttr("data.ripedb_less_specifics.test", "objects.0.first_address", "192.0.0.0"),
+					resource.TestCheckResourceAttr("data.ripedb_less_specifics.test", "objects.0.mnt_by.0", "TEST-MNT"),
+					resource.TestCheckResourceAttr("data.ripedb_more_specifics.test", "objects.#", "1"),
+					resource.TestCheckResourceAttr("data.ripedb_more_specifics.test", "objects.0.last_address", "192.0.2.127"),
+				),
+			},
+		},
+	})
+}
+
+// unsortedSearchClient returns the objects of its searches as they are.
+type unsortedSearchClient struct {
+	RipeDbClient
+	objects []*rpsl.Object
+}
+
+func (c *unsortedSearchClient) Search(ctx context.Context, opts SearchOptions) ([]*rpsl.Object, error) {
+	return c.objects, nil
+}
+
+func TestSpecificsDataSource_Sorted(t *testing.T) {
+	client := &unsortedSearchClient{}
+	for _, text := range []string{
+		"inetnum: 192.0.2.0 - 192.0.2.127\nsource: TEST\n",
+		"inetnum: 192.0.0.0 - 192.0.255.255\nsource: TEST\n",
+		"inetnum: 192.0.2.0 - 192.0.2.255\nsource: TEST\n",
+	} {
+		obj, err := rpsl.Parse(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client.objects = append(client.objects, obj)
+	}
+
+	state := testReadDataSource(t, NewLessSpecificsDataSource(), client, map[string]tftypes.Value{
+		"class": tftypes.NewValue(tftypes.String, "inetnum"),
+		"value": tftypes.NewValue(tftypes.String, "192.0.2.0/26"),
+	})
+
+	var data SpecificsDataSourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	keys := []string{}
+	for _, obj := range data.Objects {
+		keys = append(keys, obj.Value.ValueString())
+	}
+
+	expected := []string{"192.0.0.0 - 192.0.255.255", "192.0.2.0 - 192.0.2.255", "192.0.2.0 - 192.0.2.127"}
+	if !slices.Equal(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
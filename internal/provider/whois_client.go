@@ -0,0 +1,311 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frederic-arr/rpsl-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure WhoisClient satisfies the RipeDbClient interface.
+var _ RipeDbClient = &WhoisClient{}
+
+const DefaultWhoisAddress = "whois.ripe.net:43"
+
+// whoisNoEntries is the code of the whois error returned when a query matches
+// no object.
+const whoisNoEntries = 101
+
+// errReadOnlyWhois is returned by the write operations of the whois backend.
+var errReadOnlyWhois = errors.New("the whois backend is read-only, objects cannot be created, updated or deleted")
+
+// WhoisError is a `%ERROR` line returned by a whois server.
+type WhoisError struct {
+	Code    int
+	Message string
+}
+
+func (e *WhoisError) Error() string {
+	return fmt.Sprintf("RIPE database whois error %d: %s", e.Code, e.Message)
+}
+
+// WhoisClientOptions configures a WhoisClient.
+type WhoisClientOptions struct {
+	Address string
+	Source  string
+
+	Retry RetryOptions
+	Limit LimitOptions
+}
+
+// WhoisClient looks up objects over the whois protocol (port 43), for
+// environments only allowing whois egress. Every query opens a new
+// connection, which goes through the same limits and retries as the requests
+// of the REST API.
+type WhoisClient struct {
+	address string
+	source  string
+	timeout time.Duration
+	retry   RetryOptions
+	limiter *limiter
+
+	// sleep is replaced in tests to avoid waiting.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func NewWhoisClient(opts *WhoisClientOptions) *WhoisClient {
+	return &WhoisClient{
+		address: opts.Address,
+		source:  opts.Source,
+		timeout: 30 * time.Second,
+		retry:   opts.Retry,
+		limiter: newLimiter(opts.Limit),
+		sleep:   waitContext,
+	}
+}
+
+func (c *WhoisClient) GetSource() string {
+	return c.source
+}
+
+func (c *WhoisClient) GetSkipValidation() bool {
+	return false
+}
+
+func (c *WhoisClient) GetSkipUnknownKeys() bool {
+	return false
+}
+
+// GetObject runs a non-recursive, unfiltered and ungrouped query for the
+// class, and returns the object whose primary key matches.
+func (c *WhoisClient) GetObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error) {
+	return c.GetObjectWithOptions(ctx, source, resource, key, true)
+}
+
+// GetObjectWithOptions runs a non-recursive and ungrouped query for the class,
+// with the `-B` flag when unfiltered, and returns the object whose primary key
+// matches.
+func (c *WhoisClient) GetObjectWithOptions(ctx context.Context, source string, resource string, key string, unfiltered bool) (*rpsl.Object, error) {
+	if source == "" {
+		source = c.source
+	}
+
+	flags := []string{"-r"}
+	if unfiltered {
+		flags = append(flags, "-B")
+	}
+
+	objects, err := c.query(ctx, append(flags, "-G", "-T", resource, "-s", source), key)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		if strings.EqualFold(objectKey(resource, obj), key) {
+			return obj, nil
+		}
+	}
+
+	return nil, &WhoisError{Code: whoisNoEntries, Message: "no entries found"}
+}
+
+func (c *WhoisClient) CreateObjectWithOptions(ctx context.Context, source string, resource string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error) {
+	return nil, errReadOnlyWhois
+}
+
+func (c *WhoisClient) UpdateObjectWithOptions(ctx context.Context, source string, resource string, key string, object *rpsl.Object, skipValidation bool, skipUnknownKeys bool, skipKeys []string) (*rpsl.Object, error) {
+	return nil, errReadOnlyWhois
+}
+
+func (c *WhoisClient) DeleteObject(ctx context.Context, source string, resource string, key string) (*rpsl.Object, error) {
+	return nil, errReadOnlyWhois
+}
+
+// Search translates the options to whois flags. A query matching nothing
+// returns no objects rather than an error.
+func (c *WhoisClient) Search(ctx context.Context, opts SearchOptions) ([]*rpsl.Object, error) {
+	sources := opts.Sources
+	if len(sources) == 0 {
+		sources = []string{c.source}
+	}
+
+	flags := []string{"-s", strings.Join(sources, ",")}
+	if len(opts.TypeFilters) > 0 {
+		flags = append(flags, "-T", strings.Join(opts.TypeFilters, ","))
+	}
+
+	if len(opts.InverseAttributes) > 0 {
+		flags = append(flags, "-i", strings.Join(opts.InverseAttributes, ","))
+	}
+
+	for _, flag := range opts.Flags {
+		flags = append(flags, "-"+flag)
+	}
+
+	if opts.Unfiltered && !slices.Contains(opts.Flags, "B") {
+		flags = append(flags, "-B")
+	}
+
+	objects, err := c.query(ctx, flags, opts.QueryString)
+	if isNotFound(err) {
+		return []*rpsl.Object{}, nil
+	}
+
+	return objects, err
+}
+
+// query sends a query and parses the objects of the response. The first
+// `%ERROR` line of the response is returned as a WhoisError.
+func (c *WhoisClient) query(ctx context.Context, flags []string, query string) ([]*rpsl.Object, error) {
+	ctx = newLogContext(ctx)
+	if strings.ContainsAny(query, "\r\n") {
+		return nil, fmt.Errorf("invalid whois query %q", query)
+	}
+
+	line := strings.Join(append(flags, "--", query), " ")
+	fields := map[string]interface{}{
+		"address": c.address,
+		"query":   line,
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending whois query", fields)
+
+	start := time.Now()
+	response, err := c.sendWithRetries(ctx, line)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.SubsystemError(ctx, logSubsystem, "Whois query failed", merge(fields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+		return nil, err
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received whois response", fields)
+
+	objects, err := parseWhoisResponse(response)
+	for _, obj := range objects {
+		tflog.SubsystemTrace(ctx, logSubsystem, "Whois response object", merge(fields, map[string]interface{}{
+			"body": redactObject(obj).String(),
+		}))
+	}
+
+	return objects, err
+}
+
+// sendWithRetries sends a query, retrying it after a network error. The
+// queries are read-only, so every failed attempt can be retried.
+func (c *WhoisClient) sendWithRetries(ctx context.Context, line string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := c.send(ctx, line)
+		// Never retry a query which was cancelled by Terraform.
+		if err == nil || attempt >= c.retry.MaxRetries || ctx.Err() != nil {
+			return response, err
+		}
+
+		wait := backoff(c.retry.MinWait, c.retry.MaxWait, attempt)
+		tflog.SubsystemWarn(ctx, logSubsystem, "Retrying whois query", map[string]interface{}{
+			"address": c.address,
+			"attempt": attempt + 1,
+			"error":   err.Error(),
+			"wait":    wait.String(),
+		})
+
+		if err := c.sleep(ctx, wait); err != nil {
+			return "", err
+		}
+	}
+}
+
+// send sends a query over a new connection, holding a slot of the limiter
+// until the response is read.
+func (c *WhoisClient) send(ctx context.Context, line string) (string, error) {
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	if err := conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+
+	// Unblock the read when the context is cancelled.
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if _, err := io.WriteString(conn, line+"\r\n"); err != nil {
+		return "", err
+	}
+
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		return "", err
+	}
+
+	return string(response), nil
+}
+
+// parseWhoisResponse parses the objects of a whois response, skipping the
+// comment lines and returning the first `%ERROR:<code>: <message>` line as an
+// error.
+func parseWhoisResponse(response string) ([]*rpsl.Object, error) {
+	scanner := bufio.NewScanner(strings.NewReader(response))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		rest, ok := strings.CutPrefix(line, "%ERROR:")
+		if !ok {
+			continue
+		}
+
+		code, message, _ := strings.Cut(rest, ":")
+		n, err := strconv.Atoi(strings.TrimSpace(code))
+		if err != nil {
+			return nil, &WhoisError{Message: strings.TrimSpace(rest)}
+		}
+
+		return nil, &WhoisError{Code: n, Message: strings.TrimSpace(message)}
+	}
+
+	objects := []*rpsl.Object{}
+	reader := rpsl.NewReader(strings.NewReader(strings.ReplaceAll(response, "\r\n", "\n")))
+	for {
+		obj, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, &obj)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestWhoisServer starts a TCP stand-in of a whois server, answering every
+// query with the response returned by the handler.
+func newTestWhoisServer(t *testing.T, handler func(query string) string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				query, err := bufio.NewReader(conn).ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				_, _ = io.WriteString(conn, handler(strings.TrimSpace(query)))
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestWhoisClient(t *testing.T) {
+	ctx := context.Background()
+	address := newTestWhoisServer(t, func(query string) string {
+		switch query {
+		case "-r -B -G -T aut-num -s RIPE -- AS3333":
+			return "% This is the RIPE Database query service.\r\n\r\n" +
+				"aut-num:        AS3333\r\nas-name:        RIPE-NCC-AS\r\nremarks:        first line\r\n+               second line\r\nsource:         RIPE\r\n\r\n" +
+				"% This query was served by the RIPE Database Query Service\r\n"
+		case "-r -G -T aut-num -s RIPE -- AS3333":
+			return "aut-num:        AS3333\nas-name:        RIPE-NCC-AS\nsource:         RIPE # Filtered\n\n"
+		case "-s RIPE -T route -i origin -r -- AS3333", "-s RIPE -T route -i origin -r -B -- AS3333":
+			return "route: 193.0.0.0/21\norigin: AS3333\nsource: RIPE\n\nroute: 193.0.10.0/23\norigin: AS3333\nsource: RIPE\n\n"
+		case "-r -B -G -T aut-num -s RIPE -- AS1":
+			return "% This is the RIPE Database query service.\n\n%ERROR:101: no entries found\n%\n% No entries found in source RIPE.\n"
+		default:
+			return "%ERROR:201: access denied for 127.0.0.1\n"
+		}
+	})
+
+	client := NewWhoisClient(&WhoisClientOptions{Address: address, Source: DefaultSource})
+	obj, err := client.GetObject(ctx, "", "aut-num", "AS3333")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if asName := obj.GetFirst("as-name"); asName == nil || *asName != "RIPE-NCC-AS" {
+		t.Errorf("expected the object to be parsed, got %v", obj)
+	}
+
+	objects, err := client.Search(ctx, SearchOptions{QueryString: "AS3333", TypeFilters: []string{"route"}, InverseAttributes: []string{"origin"}, Flags: []string{"r"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 {
+		t.Errorf("expected 2 routes, got %d", len(objects))
+	}
+
+	objects, err = client.Search(ctx, SearchOptions{QueryString: "AS3333", TypeFilters: []string{"route"}, InverseAttributes: []string{"origin"}, Flags: []string{"r"}, Unfiltered: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 {
+		t.Errorf("expected 2 unfiltered routes, got %d", len(objects))
+	}
+
+	obj, err = client.GetObjectWithOptions(ctx, "", "aut-num", "AS3333", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if remarks := obj.GetFirst("remarks"); remarks != nil {
+		t.Errorf("expected the filtered query to be sent, got %v", obj)
+	}
+
+	if _, err := client.GetObject(ctx, "", "aut-num", "AS1"); !isNotFound(err) {
+		t.Errorf("expected AS1 not to be found, got %v", err)
+	}
+
+	_, err = client.GetObject(ctx, "", "aut-num", "AS2")
+	var whoisErr *WhoisError
+	if !errors.As(err, &whoisErr) || whoisErr.Code != 201 || whoisErr.Message != "access denied for 127.0.0.1" {
+		t.Errorf("expected an access denied error, got %v", err)
+	}
+}
+
+func TestWhoisClient_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	address := newTestWhoisServer(t, func(query string) string {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return "aut-num: AS3333\nsource: RIPE\n\n"
+	})
+
+	client := NewWhoisClient(&WhoisClientOptions{Address: address, Source: DefaultSource, Limit: LimitOptions{MaxConcurrentRequests: 2}})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetObject(context.Background(), "", "aut-num", "AS3333"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent queries, got %d", peak.Load())
+	}
+}
+
+func TestWhoisClient_RetriesNetworkErrors(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	var queries atomic.Int32
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			if _, err := bufio.NewReader(conn).ReadString('\n'); err != nil {
+				conn.Close()
+				continue
+			}
+
+			// Reset the first connections, answer the last one.
+			if queries.Add(1) < 3 {
+				if tcp, ok := conn.(*net.TCPConn); ok {
+					_ = tcp.SetLinger(0)
+				}
+			} else {
+				_, _ = io.WriteString(conn, "%ERROR:101: no entries found\n")
+			}
+			conn.Close()
+		}
+	}()
+
+	var waits []time.Duration
+	client := NewWhoisClient(&WhoisClientOptions{
+		Address: l.Addr().String(),
+		Source:  DefaultSource,
+		Retry:   RetryOptions{MaxRetries: 3, MinWait: time.Second, MaxWait: 10 * time.Second},
+	})
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	if _, err := client.GetObject(context.Background(), "", "aut-num", "AS1"); !isNotFound(err) {
+		t.Fatalf("expected the query to be retried until AS1 is not found, got %v", err)
+	}
+
+	if queries.Load() != 3 || len(waits) != 2 {
+		t.Errorf("expected 3 queries and 2 waits, got %d and %v", queries.Load(), waits)
+	}
+
+	client.retry.MaxRetries = 0
+	queries.Store(0)
+	if _, err := client.GetObject(context.Background(), "", "aut-num", "AS1"); err == nil || isNotFound(err) {
+		t.Errorf("expected the network error without retries, got %v", err)
+	}
+}
@@ -0,0 +1,743 @@
+// Copyright (c) The RIPE DB Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ripetest provides an in-memory stand-in for the RIPE Database
+// RESTful API, so that the provider can be tested without network access or
+// writes to a real database.
+//
+// The server implements lookups, creations, updates and deletions, the search,
+// versions, abuse contact and template APIs, the error message payloads of the database, the
+// filtering of `auth` values and the authorization of changes by the
+// maintainers of an object.
+// Maintainers are authenticated by the passwords registered with SetPassword,
+// given either with HTTP basic authentication or `password` query parameters;
+// their `auth` lines are not interpreted.
+package ripetest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frederic-arr/ripedb-go/ripedb/models"
+	"github.com/frederic-arr/rpsl-go"
+
+	"terraform-provider-ripedb/internal/iprange"
+)
+
+// generatedAttributes are set by the database and ignored in requests.
+var generatedAttributes = []string{"created", "last-modified"}
+
+// filteredAttributes are removed from the objects unless the `unfiltered`
+// query parameter is set.
+var filteredAttributes = []string{"e-mail", "notify", "changed", "upd-to", "mnt-nfy", "ref-nfy", "irt-nfy"}
+
+// templateAttribute is an attribute of a class template, as returned by the
+// metadata API.
+type templateAttribute struct {
+	Name        string   `json:"name"`
+	Requirement string   `json:"requirement"`
+	Cardinality string   `json:"cardinality"`
+	Keys        []string `json:"keys"`
+}
+
+// version is a revision of an object.
+type version struct {
+	Revision  int64  `json:"revision"`
+	Date      string `json:"date"`
+	Operation string `json:"operation"`
+
+	object *rpsl.Object
+}
+
+// Server is an in-memory RIPE database. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	objects   map[string][]*rpsl.Object
+	history   map[string][]version
+	passwords map[string]string
+	templates map[string][]templateAttribute
+
+	// Now returns the time used for the `created` and `last-modified`
+	// attributes.
+	Now func() time.Time
+}
+
+// NewServer starts a new empty server. It must be closed after use.
+func NewServer() *Server {
+	s := &Server{
+		objects:   map[string][]*rpsl.Object{},
+		history:   map[string][]version{},
+		passwords: map[string]string{},
+		templates: map[string][]templateAttribute{},
+		Now:       time.Now,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search", s.search)
+	mux.HandleFunc("GET /abuse-contact/{resource}", s.abuseContact)
+	mux.HandleFunc("GET /metadata/templates/{class}", s.template)
+	mux.HandleFunc("GET /{source}/{class}/{key}/versions", s.versions)
+	mux.HandleFunc("GET /{source}/{class}/{key}/versions/{revision}", s.version)
+	mux.HandleFunc("GET /{source}/{class}/{key...}", s.lookup)
+	mux.HandleFunc("POST /{source}/{class}", s.create)
+	mux.HandleFunc("PUT /{source}/{class}/{key...}", s.update)
+	mux.HandleFunc("DELETE /{source}/{class}/{key...}", s.delete)
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// AddObject stores an object given in RPSL, without any authorization.
+func (s *Server) AddObject(source string, text string) error {
+	obj, err := rpsl.Parse(text)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source = strings.ToUpper(source)
+	class := obj.Attributes[0].Name
+	if s.find(source, class, primaryKey(class, obj)) >= 0 {
+		return fmt.Errorf("%s %s already exists in %s", class, primaryKey(class, obj), source)
+	}
+
+	s.objects[source] = append(s.objects[source], obj)
+	s.record(source, class, primaryKey(class, obj), obj)
+	return nil
+}
+
+// AddTemplate sets the template of a class, given one attribute per line as
+// in the RIPE database documentation, e.g.
+// `mntner: mandatory single primary/lookup key`.
+func (s *Server) AddTemplate(class string, text string) error {
+	attributes := []templateAttribute{}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		name, rest, ok := strings.Cut(strings.TrimSpace(line), ":")
+		fields := strings.Fields(rest)
+		if !ok || len(fields) < 2 {
+			return fmt.Errorf("invalid template line %q", line)
+		}
+
+		a := templateAttribute{
+			Name:        name,
+			Requirement: strings.ToUpper(fields[0]),
+			Cardinality: strings.ToUpper(fields[1]),
+			Keys:        []string{},
+		}
+
+		keys := strings.Join(fields[2:], " ")
+		if strings.Contains(keys, "primary") {
+			a.Keys = append(a.Keys, "PRIMARY_KEY")
+		}
+
+		if strings.Contains(keys, "lookup") {
+			a.Keys = append(a.Keys, "LOOKUP_KEY")
+		}
+
+		if strings.Contains(keys, "inverse") {
+			a.Keys = append(a.Keys, "INVERSE_KEY")
+		}
+
+		attributes = append(attributes, a)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.templates[class] = attributes
+	return nil
+}
+
+// SetPassword sets the password authenticating a maintainer.
+func (s *Server) SetPassword(mntner string, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.passwords[strings.ToUpper(mntner)] = password
+}
+
+// Object returns a copy of a stored object, or nil when it does not exist.
+func (s *Server) Object(source string, class string, key string) *rpsl.Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(strings.ToUpper(source), class, key)
+	if i < 0 {
+		return nil
+	}
+
+	return clone(s.objects[strings.ToUpper(source)][i])
+}
+
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, class, key := strings.ToUpper(r.PathValue("source")), r.PathValue("class"), r.PathValue("key")
+	i := s.find(source, class, key)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "ERROR:101: no entries found")
+		return
+	}
+
+	obj := s.objects[source][i]
+	writeObjects(w, source, s.present(r, obj))
+}
+
+func (s *Server) versions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history, ok := s.history[historyKey(strings.ToUpper(r.PathValue("source")), r.PathValue("class"), r.PathValue("key"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ERROR:101: no entries found")
+		return
+	}
+
+	res := map[string]interface{}{
+		"versions": map[string]interface{}{"version": history},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) version(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source := strings.ToUpper(r.PathValue("source"))
+	history := s.history[historyKey(source, r.PathValue("class"), r.PathValue("key"))]
+	i := slices.IndexFunc(history, func(v version) bool { return strconv.FormatInt(v.Revision, 10) == r.PathValue("revision") })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "There is no entry for object %s for the supplied version.", r.PathValue("key"))
+		return
+	}
+
+	writeObjects(w, source, s.present(r, history[i].object))
+}
+
+// record adds a revision to the history of an object.
+func (s *Server) record(source string, class string, key string, obj *rpsl.Object) {
+	k := historyKey(source, class, key)
+	s.history[k] = append(s.history[k], version{
+		Revision:  int64(len(s.history[k]) + 1),
+		Date:      s.Now().UTC().Format("2006-01-02 15:04"),
+		Operation: "ADD/UPD",
+		object:    clone(obj),
+	})
+}
+
+func historyKey(source string, class string, key string) string {
+	return source + "/" + class + "/" + strings.ToUpper(key)
+}
+
+func (s *Server) template(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	class := r.PathValue("class")
+	attributes, ok := s.templates[class]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid object type: %s", class)
+		return
+	}
+
+	res := map[string]interface{}{
+		"templates": map[string]interface{}{
+			"template": []interface{}{
+				map[string]interface{}{
+					"type":       class,
+					"attributes": map[string]interface{}{"attribute": attributes},
+				},
+			},
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// abuseContact resolves the `abuse-c` of the aut-num, or of the most specific
+// inetnum or inet6num covering the resource, falling back to the `abuse-c` of
+// its organisation.
+func (s *Server) abuseContact(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource := r.PathValue("resource")
+	source, obj := s.resourceObject(resource)
+	if obj == nil {
+		writeError(w, http.StatusNotFound, "ERROR:101: no entries found")
+		return
+	}
+
+	org := valueOr(obj.GetFirst("org"), "")
+	abuseC := obj.GetFirst("abuse-c")
+	if i := s.find(source, "organisation", org); abuseC == nil && i >= 0 {
+		abuseC = s.objects[source][i].GetFirst("abuse-c")
+	}
+
+	role := -1
+	if abuseC != nil {
+		role = s.find(source, "role", *abuseC)
+	}
+
+	if role < 0 || s.objects[source][role].GetFirst("abuse-mailbox") == nil {
+		writeError(w, http.StatusNotFound, "No abuse contact found for %s", resource)
+		return
+	}
+
+	res := map[string]interface{}{
+		"parameters": map[string]interface{}{
+			"primary-key": map[string]string{"value": primaryKey(obj.Attributes[0].Name, obj)},
+		},
+		"abuse-contacts": map[string]interface{}{
+			"key":     *abuseC,
+			"email":   *s.objects[source][role].GetFirst("abuse-mailbox"),
+			"suspect": false,
+			"org-id":  org,
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// resourceObject returns the aut-num of an AS number, or the most specific
+// inetnum or inet6num covering an address, prefix or range, with its source.
+func (s *Server) resourceObject(resource string) (string, *rpsl.Object) {
+	for _, source := range slices.Sorted(maps.Keys(s.objects)) {
+		if i := s.find(source, "aut-num", resource); i >= 0 {
+			return source, s.objects[source][i]
+		}
+	}
+
+	query, err := iprange.Parse(resource)
+	if err != nil {
+		return "", nil
+	}
+
+	var found *rpsl.Object
+	var foundSource string
+	var foundRange iprange.Range
+	for _, source := range slices.Sorted(maps.Keys(s.objects)) {
+		for _, obj := range s.objects[source] {
+			class := obj.Attributes[0].Name
+			if class != "inetnum" && class != "inet6num" {
+				continue
+			}
+
+			r, err := iprange.ObjectRange(obj)
+			if err != nil || !r.Contains(query) {
+				continue
+			}
+
+			// The ranges are nested, a more specific range is within the
+			// one found so far.
+			if found == nil || foundRange.Contains(r) {
+				found, foundSource, foundRange = obj, source, r
+			}
+		}
+	}
+
+	return foundSource, found
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := r.URL.Query()
+	query := q.Get("query-string")
+	inverse := q["inverse-attribute"]
+	types := q["type-filter"]
+
+	sources := q["source"]
+	if len(sources) == 0 {
+		sources = []string{"RIPE"}
+	}
+
+	objects := []*rpsl.Object{}
+	for _, source := range sources {
+		candidates := []*rpsl.Object{}
+		for _, obj := range s.objects[strings.ToUpper(source)] {
+			class := obj.Attributes[0].Name
+			if len(types) > 0 && !slices.Contains(types, class) {
+				continue
+			}
+
+			candidates = append(candidates, obj)
+		}
+
+		if i := slices.IndexFunc(q["flags"], func(f string) bool { return slices.Contains(iprange.HierarchyFlags, f) }); i >= 0 {
+			r, err := iprange.Parse(query)
+			if err != nil {
+				candidates = nil
+			} else {
+				candidates = iprange.Hierarchy(r, candidates, q["flags"][i])
+			}
+		} else {
+			candidates = slices.DeleteFunc(candidates, func(obj *rpsl.Object) bool {
+				return !matches(obj, obj.Attributes[0].Name, query, inverse)
+			})
+		}
+
+		for _, obj := range candidates {
+			objects = append(objects, s.present(r, obj))
+		}
+	}
+
+	if len(objects) == 0 {
+		writeError(w, http.StatusNotFound, "ERROR:101: no entries found")
+		return
+	}
+
+	writeObjects(w, strings.ToUpper(sources[0]), objects...)
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, class := strings.ToUpper(r.PathValue("source")), r.PathValue("class")
+	obj, ok := s.readObject(w, r, source, class)
+	if !ok {
+		return
+	}
+
+	key := primaryKey(class, obj)
+	if s.find(source, class, key) >= 0 {
+		writeError(w, http.StatusBadRequest, "Enforced new keyword specified, but the object already exists in the database")
+		return
+	}
+
+	if !s.checkReferences(w, source, class, key, obj) || !s.authorize(w, r, source, class, key, obj, obj) {
+		return
+	}
+
+	now := s.Now().UTC().Format(time.RFC3339)
+	obj = withGenerated(obj, now, now)
+	if !isDryRun(r) {
+		s.objects[source] = append(s.objects[source], obj)
+		s.record(source, class, key, obj)
+	}
+
+	writeObjects(w, source, s.present(r, obj))
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, class, key := strings.ToUpper(r.PathValue("source")), r.PathValue("class"), r.PathValue("key")
+	i := s.find(source, class, key)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "ERROR:101: no entries found")
+		return
+	}
+
+	obj, ok := s.readObject(w, r, source, class)
+	if !ok {
+		return
+	}
+
+	if !strings.EqualFold(primaryKey(class, obj), key) {
+		writeError(w, http.StatusBadRequest, "Primary key (%s) cannot be modified", key)
+		return
+	}
+
+	current := s.objects[source][i]
+	if !s.checkReferences(w, source, class, key, obj) || !s.authorize(w, r, source, class, key, current, obj) {
+		return
+	}
+
+	created := ""
+	if v := current.GetFirst("created"); v != nil {
+		created = *v
+	}
+
+	obj = withGenerated(obj, created, s.Now().UTC().Format(time.RFC3339))
+	if !isDryRun(r) {
+		s.objects[source][i] = obj
+		s.record(source, class, key, obj)
+	}
+
+	writeObjects(w, source, s.present(r, obj))
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, class, key := strings.ToUpper(r.PathValue("source")), r.PathValue("class"), r.PathValue("key")
+	i := s.find(source, class, key)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "ERROR:101: no entries found")
+		return
+	}
+
+	obj := s.objects[source][i]
+	if !s.authorize(w, r, source, class, key, obj, obj) {
+		return
+	}
+
+	for j, other := range s.objects[source] {
+		if j != i && references(other, key) {
+			writeError(w, http.StatusBadRequest, "Object [%s] %s is referenced from other objects", class, key)
+			return
+		}
+	}
+
+	if !isDryRun(r) {
+		s.objects[source] = slices.Delete(s.objects[source], i, i+1)
+	}
+
+	writeObjects(w, source, s.present(r, obj))
+}
+
+// readObject decodes the object of a create or update request, and checks
+// that it matches the class and source of the URL.
+func (s *Server) readObject(w http.ResponseWriter, r *http.Request, source string, class string) (*rpsl.Object, bool) {
+	res := models.Resource{}
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: %s", err.Error())
+		return nil, false
+	}
+
+	m, err := res.FindOne()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: %s", err.Error())
+		return nil, false
+	}
+
+	obj, err := models.ModelObjectToRpslObject(m)
+	if err != nil || len(obj.Attributes) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid request body: the object has no attributes")
+		return nil, false
+	}
+
+	if obj.Attributes[0].Name != class {
+		writeError(w, http.StatusBadRequest, "Object type specified in URI (%s) is not the same as the object type in the request body (%s)", class, obj.Attributes[0].Name)
+		return nil, false
+	}
+
+	if v := obj.GetFirst("source"); v == nil || !strings.EqualFold(*v, source) {
+		writeError(w, http.StatusBadRequest, "Unrecognized source: %s", valueOr(v, ""))
+		return nil, false
+	}
+
+	obj.Attributes = slices.DeleteFunc(obj.Attributes, func(a rpsl.Attribute) bool {
+		return slices.Contains(generatedAttributes, a.Name) || a.Name == "dry-run"
+	})
+
+	return obj, true
+}
+
+// checkReferences checks that the maintainers of an object exist. A
+// maintainer can reference itself.
+func (s *Server) checkReferences(w http.ResponseWriter, source string, class string, key string, obj *rpsl.Object) bool {
+	for _, mntner := range obj.GetAll("mnt-by") {
+		if class == "mntner" && strings.EqualFold(mntner, key) {
+			continue
+		}
+
+		if s.find(source, "mntner", mntner) < 0 {
+			writeError(w, http.StatusBadRequest, "Unknown object referenced %s", mntner)
+			return false
+		}
+	}
+
+	return true
+}
+
+// authorize checks that one of the maintainers of the current object is
+// authenticated by the request. The new version of a maintainer authorizes
+// its own creation.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, source string, class string, key string, current *rpsl.Object, obj *rpsl.Object) bool {
+	mntners := current.GetAll("mnt-by")
+	if len(mntners) == 0 {
+		mntners = obj.GetAll("mnt-by")
+	}
+
+	if slices.ContainsFunc(mntners, func(mntner string) bool { return s.authenticated(r, mntner) }) {
+		return true
+	}
+
+	writeError(w, http.StatusUnauthorized, "Authorisation for [%s] %s failed\nusing \"%s:\"\nnot authenticated by: %s", class, key, "mnt-by", strings.Join(mntners, ", "))
+	return false
+}
+
+// authenticated reports whether the request gives the password of the
+// maintainer.
+func (s *Server) authenticated(r *http.Request, mntner string) bool {
+	password, ok := s.passwords[strings.ToUpper(mntner)]
+	if !ok {
+		return false
+	}
+
+	passwords := r.URL.Query()["password"]
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Basic ") {
+		if decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "Basic ")); err == nil {
+			if _, p, ok := strings.Cut(string(decoded), ":"); ok {
+				passwords = append(passwords, p)
+			}
+		}
+	}
+
+	return slices.Contains(passwords, password)
+}
+
+// present returns the object as returned to the request: `auth` values are
+// filtered unless the `unfiltered` query parameter is set and one of the
+// maintainers of the object is authenticated, and contact attributes are
+// removed unless the `unfiltered` query parameter is set.
+func (s *Server) present(r *http.Request, obj *rpsl.Object) *rpsl.Object {
+	c := clone(obj)
+	unfiltered := r.URL.Query().Has("unfiltered")
+	if !unfiltered || !slices.ContainsFunc(obj.GetAll("mnt-by"), func(mntner string) bool { return s.authenticated(r, mntner) }) {
+		for i, a := range c.Attributes {
+			if a.Name == "auth" {
+				scheme, _, _ := strings.Cut(a.Value, " ")
+				c.Attributes[i].Value = scheme + " # Filtered"
+			}
+		}
+	}
+
+	if !unfiltered {
+		c.Attributes = slices.DeleteFunc(c.Attributes, func(a rpsl.Attribute) bool {
+			return slices.Contains(filteredAttributes, a.Name)
+		})
+
+		for i, a := range c.Attributes {
+			if a.Name == "source" {
+				c.Attributes[i].Value = a.Value + " # Filtered"
+			}
+		}
+	}
+
+	return c
+}
+
+// find returns the index of an object in a source, or -1.
+func (s *Server) find(source string, class string, key string) int {
+	return slices.IndexFunc(s.objects[source], func(obj *rpsl.Object) bool {
+		return obj.Attributes[0].Name == class && strings.EqualFold(primaryKey(class, obj), key)
+	})
+}
+
+// primaryKey returns the primary key of an object, as used in the URLs.
+func primaryKey(class string, obj *rpsl.Object) string {
+	if m := models.ObjectToModelUnchecked(class, *obj); m != nil {
+		return m.Key()
+	}
+
+	return obj.Attributes[0].Value
+}
+
+// matches reports whether an object matches a search query. Without inverse
+// attributes, the query is matched against the primary key of the object.
+func matches(obj *rpsl.Object, class string, query string, inverse []string) bool {
+	if len(inverse) == 0 {
+		return strings.EqualFold(primaryKey(class, obj), query)
+	}
+
+	return slices.ContainsFunc(obj.Attributes, func(a rpsl.Attribute) bool {
+		return slices.Contains(inverse, a.Name) && strings.EqualFold(a.Value, query)
+	})
+}
+
+// references reports whether one of the attributes of an object (but the
+// first one) has the key as value.
+func references(obj *rpsl.Object, key string) bool {
+	return slices.ContainsFunc(obj.Attributes[1:], func(a rpsl.Attribute) bool {
+		return strings.EqualFold(a.Value, key)
+	})
+}
+
+// withGenerated returns a copy of the object with the `created` and
+// `last-modified` attributes inserted before its source.
+func withGenerated(obj *rpsl.Object, created string, lastModified string) *rpsl.Object {
+	c := clone(obj)
+	i := slices.IndexFunc(c.Attributes, func(a rpsl.Attribute) bool { return a.Name == "source" })
+	generated := []rpsl.Attribute{}
+	if created != "" {
+		generated = append(generated, rpsl.Attribute{Name: "created", Value: created})
+	}
+
+	generated = append(generated, rpsl.Attribute{Name: "last-modified", Value: lastModified})
+	c.Attributes = slices.Insert(c.Attributes, i, generated...)
+	return c
+}
+
+func isDryRun(r *http.Request) bool {
+	return r.URL.Query().Has("dry-run")
+}
+
+func clone(obj *rpsl.Object) *rpsl.Object {
+	return &rpsl.Object{Attributes: slices.Clone(obj.Attributes)}
+}
+
+func valueOr(v *string, fallback string) string {
+	if v == nil {
+		return fallback
+	}
+
+	return *v
+}
+
+// writeObjects writes the objects payload. The end of line comments, e.g. the
+// `# Filtered` marks, are sent apart from the values like the RIPE database
+// does.
+func writeObjects(w http.ResponseWriter, source string, objects ...*rpsl.Object) {
+	res := models.Resource{Objects: &models.Objects{Object: []models.Object{}}}
+	for _, obj := range objects {
+		class := obj.Attributes[0].Name
+		m := models.NewObjectFromRpslObject(obj)
+		for i, a := range obj.Attributes {
+			if value, comment, ok := strings.Cut(a.Value, " # "); ok {
+				m.Attributes.Attribute[i].Value = value
+				m.Attributes.Attribute[i].Comment = &comment
+			}
+		}
+
+		m.Type = &class
+		m.Source = &models.Source{ID: strings.ToLower(source)}
+		res.Objects.Object = append(res.Objects.Object, m)
+	}
+
+	writeResource(w, http.StatusOK, &res)
+}
+
+// writeError writes an error message payload. The text is a format string
+// whose arguments are sent separately, like the RIPE database does.
+func writeError(w http.ResponseWriter, status int, text string, args ...string) {
+	severity := "Error"
+	msg := models.ObjectMessage{Severity: &severity, Text: &text}
+	for _, arg := range args {
+		msg.Args = append(msg.Args, models.ObjectMessageArgValue{Value: arg})
+	}
+
+	writeResource(w, status, &models.Resource{
+		ErrorMessages: &models.ErrorMessages{ErrorMessage: []models.ObjectMessage{msg}},
+	})
+}
+
+func writeResource(w http.ResponseWriter, status int, res *models.Resource) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}